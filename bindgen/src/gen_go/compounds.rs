@@ -10,15 +10,33 @@ use uniffi_bindgen::{
 
 use super::CodeType;
 
-fn render_literal(literal: &Literal, inner: &Type, ci: &ComponentInterface) -> String {
+fn render_literal(
+    literal: &Literal,
+    type_label: &str,
+    inner: &Type,
+    ci: &ComponentInterface,
+) -> String {
     match literal {
-        Literal::None => "nil".into(),
+        // Empty sequences are lifted as nil, while empty maps are allocated
+        Literal::None | Literal::EmptySequence => "nil".into(),
+        Literal::EmptyMap => format!("{type_label}{{}}"),
+        Literal::Some { inner: value } => render_pointer(value, inner, ci),
 
         // For optionals
-        _ => super::GoCodeOracle.find(inner, ci).literal(literal, ci),
+        _ => render_pointer(literal, inner, ci),
     }
 }
 
+/// Go can't take the address of a literal, so it is stored in a variable first.
+fn render_pointer(literal: &Literal, inner: &Type, ci: &ComponentInterface) -> String {
+    let code_type = super::GoCodeOracle.find(inner, ci);
+    let type_label = code_type.type_label(ci);
+    format!(
+        "func() *{type_label} {{ var v {type_label} = {}; return &v }}()",
+        code_type.literal(literal, ci)
+    )
+}
+
 macro_rules! impl_code_type_for_compound {
      ($T:ty, $type_label_pattern:literal, $canonical_name_pattern: literal) => {
          paste! {
@@ -47,7 +65,11 @@ macro_rules! impl_code_type_for_compound {
                  }
 
                  fn literal(&self, literal: &Literal, ci: &ComponentInterface) -> String {
-                     render_literal(literal, self.inner(), ci)
+                     render_literal(literal, &self.type_label(ci), self.inner(), ci)
+                 }
+
+                 fn default_literal(&self, _ci: &ComponentInterface) -> String {
+                     "nil".into()
                  }
              }
          }
@@ -100,6 +122,10 @@ impl<'a> CodeType for MapCodeType<'a> {
     }
 
     fn literal(&self, literal: &Literal, ci: &ComponentInterface) -> String {
-        render_literal(literal, &self.value, ci)
+        render_literal(literal, &self.type_label(ci), &self.value, ci)
+    }
+
+    fn default_literal(&self, ci: &ComponentInterface) -> String {
+        format!("{}{{}}", self.type_label(ci))
     }
 }
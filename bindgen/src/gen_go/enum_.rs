@@ -33,11 +33,11 @@ impl CodeType for EnumCodeType {
 
     fn literal(&self, literal: &Literal, ci: &ComponentInterface) -> String {
         if let Literal::Enum(v, _) = literal {
-            format!(
-                "{}.{}",
-                self.type_label(ci),
-                super::GoCodeOracle.enum_variant_name(v)
-            )
+            let name = self.canonical_name();
+            match ci.get_enum_definition(&self.name) {
+                Some(e) if !e.is_flat() => format!("{name}{}{{}}", oracle().class_name(v)),
+                _ => format!("{name}{}", oracle().enum_variant_name(v)),
+            }
         } else {
             unreachable!();
         }
@@ -1,5 +1,5 @@
 use heck::ToShoutySnakeCase;
-use uniffi_meta::LiteralMetadata;
+use uniffi_meta::{DefaultValueMetadata, LiteralMetadata};
 
 use super::*;
 
@@ -49,6 +49,13 @@ pub fn write_fn<'a>(
     Ok(oracle().find(type_, ci).write())
 }
 
+pub fn allocation_size_fn<'a>(
+    type_: &impl AsType,
+    ci: &'a ComponentInterface,
+) -> Result<String, askama::Error> {
+    Ok(oracle().find(type_, ci).allocation_size())
+}
+
 pub fn lower_fn<'a>(
     type_: &impl AsType,
     ci: &'a ComponentInterface,
@@ -70,6 +77,153 @@ pub fn requires_lower_external<'a>(
     Ok(oracle().find(type_, ci).requires_lower_external())
 }
 
+/// Whether lowering an argument of this type can fail on user input, such arguments are
+/// lowered before the FFI call so that the failure can be returned as a `*LowerError`.
+/// Objects fail to lower once destroyed.
+pub fn lower_can_fail<'a>(
+    type_: &impl AsType,
+    _ci: &'a ComponentInterface,
+) -> Result<bool, askama::Error> {
+    let type_ = type_.as_type();
+    Ok(matches!(
+        type_,
+        Type::Custom { .. } | Type::Object { .. } | Type::CallbackInterface { .. }
+    ) || matches!(FfiType::from(&type_), FfiType::RustBuffer(_)))
+}
+
+/// Whether the converter of this type writes the object handles of nested values into its
+/// buffer, which have to be released again when the value never reaches Rust. Custom types
+/// share the converter of their builtin type.
+pub fn writes_handles<'a>(
+    type_: &impl AsType,
+    ci: &'a ComponentInterface,
+) -> Result<bool, askama::Error> {
+    let type_ = type_.as_type();
+    Ok(matches!(
+        type_,
+        Type::Record { .. }
+            | Type::Enum { .. }
+            | Type::Optional { .. }
+            | Type::Sequence { .. }
+            | Type::Map { .. }
+    ) && contains_handles(&type_, ci, &mut HashSet::new()))
+}
+
+/// Whether the converter of this type converts custom types while writing. Their `from_custom`
+/// is only run once per lowering, so such values are written without computing their size first.
+/// External types may hold custom types of their own.
+pub fn writes_custom_types<'a>(
+    type_: &impl AsType,
+    ci: &'a ComponentInterface,
+) -> Result<bool, askama::Error> {
+    let type_ = type_.as_type();
+    Ok(matches!(
+        type_,
+        Type::Record { .. }
+            | Type::Enum { .. }
+            | Type::Optional { .. }
+            | Type::Sequence { .. }
+            | Type::Map { .. }
+            | Type::Custom { .. }
+    ) && contains_type(&type_, ci, &mut HashSet::new(), &|nested| {
+        matches!(nested, Type::Custom { .. }) || ci.is_external(nested)
+    }))
+}
+
+fn contains_handles(type_: &Type, ci: &ComponentInterface, seen: &mut HashSet<String>) -> bool {
+    contains_type(type_, ci, seen, &|nested| {
+        matches!(nested, Type::Object { .. } | Type::CallbackInterface { .. })
+            && !ci.is_external(nested)
+    })
+}
+
+/// Whether the type, or any type nested in it, matches. Records and enums are visited once.
+fn contains_type(
+    type_: &Type,
+    ci: &ComponentInterface,
+    seen: &mut HashSet<String>,
+    matches: &dyn Fn(&Type) -> bool,
+) -> bool {
+    if matches(type_) {
+        return true;
+    }
+    // External types are written by the package defining them
+    if ci.is_external(type_) {
+        return false;
+    }
+    match type_ {
+        Type::Optional { inner_type } | Type::Sequence { inner_type } => {
+            contains_type(inner_type, ci, seen, matches)
+        }
+        Type::Map {
+            key_type,
+            value_type,
+        } => {
+            contains_type(key_type, ci, seen, matches)
+                || contains_type(value_type, ci, seen, matches)
+        }
+        Type::Custom { builtin, .. } => contains_type(builtin, ci, seen, matches),
+        Type::Record { name, .. } => {
+            seen.insert(name.clone())
+                && ci.get_record_definition(name).is_some_and(|rec| {
+                    rec.fields()
+                        .iter()
+                        .any(|f| contains_type(&f.as_type(), ci, seen, matches))
+                })
+        }
+        Type::Enum { name, .. } => {
+            seen.insert(name.clone())
+                && ci.get_enum_definition(name).is_some_and(|e| {
+                    e.variants().iter().any(|v| {
+                        v.fields()
+                            .iter()
+                            .any(|f| contains_type(&f.as_type(), ci, seen, matches))
+                    })
+                })
+        }
+        _ => false,
+    }
+}
+
+/// Statement releasing a lowered value that never reached Rust. Handles are released by their
+/// converter, values holding handles are lifted and destroyed again, so that the handles are
+/// released along with the buffer.
+pub fn release_lowered<'a>(
+    type_: &impl AsType,
+    name: &str,
+    ci: &'a ComponentInterface,
+) -> Result<String, askama::Error> {
+    let type_ = type_.as_type();
+    if !contains_handles(&type_, ci, &mut HashSet::new()) {
+        return Ok(format!("uniffiFreeLowered({name})"));
+    }
+    let code_type = oracle().find(&type_, ci);
+    if matches!(type_, Type::Object { .. } | Type::CallbackInterface { .. }) {
+        return Ok(format!(
+            "{}.release(uint64({name}))",
+            code_type.ffi_converter_instance()
+        ));
+    }
+    let lowered = match FfiType::from(&type_) {
+        FfiType::RustBuffer(_) => format!("GoRustBuffer{{inner: {name}}}"),
+        _ => name.to_string(),
+    };
+    Ok(format!(
+        "{}({}({lowered}))",
+        code_type.destroy(),
+        code_type.lift()
+    ))
+}
+
+/// Same as `release_lowered`, for the argument lowered into `_uniffiArg<index>`
+pub fn release_lowered_arg<'a>(
+    arg: &Argument,
+    index: &usize,
+    ci: &'a ComponentInterface,
+) -> Result<String, askama::Error> {
+    release_lowered(arg, &format!("_uniffiArg{index}"), ci)
+}
+
 pub fn destroy_fn<'a>(
     type_: &impl AsType,
     ci: &'a ComponentInterface,
@@ -218,6 +372,113 @@ pub fn variant_discr_literal(e: &Enum, index: &usize) -> Result<String, askama::
     }
 }
 
+/// Get the Go rendering of the default value of a field or argument
+pub fn default_value<'a>(
+    default: &DefaultValueMetadata,
+    type_: &impl AsType,
+    ci: &'a ComponentInterface,
+) -> Result<String, askama::Error> {
+    let code_type = oracle().find(type_, ci);
+    Ok(match default {
+        DefaultValueMetadata::Default => code_type.default_literal(ci),
+        DefaultValueMetadata::Literal(literal) => code_type.literal(literal, ci),
+    })
+}
+
+/// Whether the default can be written as a Go expression. Values of custom, external and
+/// time types, as well as objects, can't, and the defaults of enums and records are only
+/// known for literals and records with a default for every field.
+fn has_go_default(type_: &Type, default: &DefaultValueMetadata, ci: &ComponentInterface) -> bool {
+    if ci.is_external(type_) {
+        return false;
+    }
+    match (type_, default) {
+        (
+            Type::Boolean
+            | Type::String
+            | Type::Bytes
+            | Type::Int8
+            | Type::UInt8
+            | Type::Int16
+            | Type::UInt16
+            | Type::Int32
+            | Type::UInt32
+            | Type::Int64
+            | Type::UInt64
+            | Type::Float32
+            | Type::Float64
+            | Type::Optional { .. }
+            | Type::Sequence { .. }
+            | Type::Map { .. },
+            DefaultValueMetadata::Default,
+        ) => true,
+        (Type::Record { name, .. }, DefaultValueMetadata::Default) => ci
+            .get_record_definition(name)
+            .is_some_and(|rec| rec.fields().iter().all(|f| has_field_default(f, ci))),
+        (Type::Optional { inner_type }, DefaultValueMetadata::Literal(literal)) => match literal {
+            LiteralMetadata::None => true,
+            LiteralMetadata::Some { inner } => has_go_default(
+                inner_type,
+                &DefaultValueMetadata::Literal((**inner).clone()),
+                ci,
+            ),
+            _ => has_go_default(inner_type, default, ci),
+        },
+        (Type::Custom { .. } | Type::Object { .. } | Type::CallbackInterface { .. }, _) => false,
+        (Type::Timestamp | Type::Duration | Type::Enum { .. } | Type::Record { .. }, _) => {
+            matches!(
+                default,
+                DefaultValueMetadata::Literal(LiteralMetadata::Enum(..))
+            )
+        }
+        (_, DefaultValueMetadata::Literal(_)) => true,
+    }
+}
+
+fn has_field_default(field: &Field, ci: &ComponentInterface) -> bool {
+    field
+        .default_value()
+        .is_some_and(|default| has_go_default(&field.as_type(), default, ci))
+}
+
+/// Fields without a default, which are the arguments of `New<Record>Default`. Records with
+/// no default at all, or a default that can't be written in Go, get no such constructor.
+pub fn required_fields<'a>(
+    rec: &'a Record,
+    ci: &ComponentInterface,
+) -> Result<Option<Vec<&'a Field>>, askama::Error> {
+    let fields = rec.fields();
+    if !fields.iter().any(|f| has_field_default(f, ci))
+        || fields
+            .iter()
+            .any(|f| f.default_value().is_some() && !has_field_default(f, ci))
+    {
+        return Ok(None);
+    }
+    Ok(Some(
+        fields
+            .iter()
+            .filter(|f| f.default_value().is_none())
+            .collect(),
+    ))
+}
+
+/// Index of the first of the trailing arguments with a default, which can be set through
+/// options of `<Function>WithOptions`
+pub fn default_args_start(
+    callable: &impl Callable,
+    ci: &ComponentInterface,
+) -> Result<usize, askama::Error> {
+    Ok(callable
+        .arguments()
+        .iter()
+        .rposition(|arg| {
+            !arg.default_value()
+                .is_some_and(|default| has_go_default(&arg.as_type(), default, ci))
+        })
+        .map_or(0, |index| index + 1))
+}
+
 /// Get the idiomatic Go rendering of docstring
 pub fn docstring(docstring: &str, tabs: &i32) -> Result<String, askama::Error> {
     let docstring = textwrap::indent(&textwrap::dedent(docstring), "// ");
@@ -42,6 +42,12 @@ pub trait CodeType: std::fmt::Debug {
         unimplemented!("Unimplemented for {}", self.type_label(ci))
     }
 
+    /// The Go rendering of the value of `Default::default()` in Rust, used for fields and
+    /// arguments declared with a bare `#[uniffi(default)]`.
+    fn default_literal(&self, ci: &ComponentInterface) -> String {
+        unimplemented!("No default value for {}", self.type_label(ci))
+    }
+
     /// Name of the FfiConverter
     ///
     /// This is the object that contains the lower, write, lift, and read methods for this type.
@@ -84,6 +90,11 @@ pub trait CodeType: std::fmt::Debug {
         format!("{}.Write", self.ffi_converter_instance())
     }
 
+    /// An expression for the number of bytes that writing a value takes.
+    fn allocation_size(&self) -> String {
+        format!("{}.AllocationSize", self.ffi_converter_instance())
+    }
+
     /// An expression for lifting a value from something we received over the FFI.
     fn lift(&self) -> String {
         format!("{}.Lift", self.ffi_converter_instance())
@@ -120,6 +131,32 @@ pub struct Config {
     custom_types: HashMap<String, CustomTypeConfig>,
     #[serde(default)]
     go_mod: Option<String>,
+    #[serde(default)]
+    context_interfaces: HashSet<String>,
+    #[serde(default)]
+    streams: HashMap<String, StreamConfig>,
+    #[serde(default)]
+    runtime_cleanup: bool,
+    #[serde(default)]
+    use_after_destroy: UseAfterDestroy,
+    #[serde(default)]
+    panic_mode: PanicMode,
+    #[serde(default)]
+    lift_mode: LiftMode,
+    #[serde(default)]
+    non_exhaustive_fallback: NonExhaustiveFallback,
+    #[serde(default)]
+    string_utf8: StringUtf8,
+    #[serde(default)]
+    deferred_initialization: bool,
+    #[serde(default)]
+    borrowed_results: HashSet<String>,
+    #[serde(default)]
+    fuzz_tests: bool,
+    #[serde(default)]
+    log_valuer: bool,
+    #[serde(default)]
+    sensitive_fields: HashSet<String>,
 }
 
 impl Config {
@@ -144,6 +181,69 @@ pub struct CustomTypeConfig {
     from_custom: String,
 }
 
+#[derive(Debug, Default, Clone, Serialize, Deserialize)]
+pub struct StreamConfig {
+    method: Option<String>,
+}
+
+/// What object methods do when called after `Destroy()`.
+#[derive(Debug, Default, Clone, Copy, PartialEq, Eq, Serialize, Deserialize)]
+#[serde(rename_all = "lowercase")]
+pub enum UseAfterDestroy {
+    #[default]
+    Panic,
+    Error,
+}
+
+/// How Rust panics are reported to Go callers.
+#[derive(Debug, Default, Clone, Copy, PartialEq, Eq, Serialize, Deserialize)]
+#[serde(rename_all = "lowercase")]
+pub enum PanicMode {
+    #[default]
+    Panic,
+    Error,
+}
+
+/// How values that can not be lifted from Rust are reported to Go callers.
+#[derive(Debug, Default, Clone, Copy, PartialEq, Eq, Serialize, Deserialize)]
+#[serde(rename_all = "lowercase")]
+pub enum LiftMode {
+    #[default]
+    Panic,
+    Error,
+}
+
+/// What happens to Go strings that are not valid UTF-8 when lowered into Rust strings.
+#[derive(Debug, Default, Clone, Copy, PartialEq, Eq, Serialize, Deserialize)]
+#[serde(rename_all = "lowercase")]
+pub enum StringUtf8 {
+    #[default]
+    Passthrough,
+    Validate,
+    Replace,
+}
+
+/// Enums and errors that lift unknown variants into a fallback value, either all or the listed ones.
+#[derive(Debug, Clone, PartialEq, Eq, Serialize, Deserialize)]
+#[serde(untagged)]
+pub enum NonExhaustiveFallback {
+    All(bool),
+    Enums(HashSet<String>),
+}
+
+impl Default for NonExhaustiveFallback {
+    fn default() -> Self {
+        NonExhaustiveFallback::All(false)
+    }
+}
+
+impl StreamConfig {
+    /// The async method yielding the next item of the stream, or `None` once it is exhausted.
+    pub fn method(&self) -> &str {
+        self.method.as_deref().unwrap_or("next")
+    }
+}
+
 impl CustomTypeConfig {
     fn lift(&self, name: &str) -> String {
         self.into_custom.replace("{}", name)
@@ -192,6 +292,183 @@ impl Config {
     pub fn c_filename(&self) -> String {
         format!("{}.c", self.c_module_filename())
     }
+
+    /// Whether async methods of the given callback or trait interface take a `context.Context`.
+    pub fn takes_context(&self, interface_name: &str) -> bool {
+        self.context_interfaces.contains(interface_name)
+    }
+
+    /// Whether objects are released with `runtime.AddCleanup` instead of `runtime.SetFinalizer`.
+    pub fn runtime_cleanup(&self) -> bool {
+        self.runtime_cleanup
+    }
+
+    /// Whether object methods report use-after-destroy as an error instead of panicking.
+    pub fn use_after_destroy_errors(&self) -> bool {
+        self.use_after_destroy == UseAfterDestroy::Error
+    }
+
+    /// Whether generated functions return Rust panics as `*RustPanicError` instead of panicking.
+    pub fn panic_errors(&self) -> bool {
+        self.panic_mode == PanicMode::Error
+    }
+
+    /// Whether generated functions return lifting failures as `*LiftError` instead of panicking.
+    pub fn lift_errors(&self) -> bool {
+        self.lift_mode == LiftMode::Error
+    }
+
+    /// Whether generated functions have an error result to return recovered panics in.
+    pub fn recovered_errors(&self) -> bool {
+        self.panic_errors() || self.lift_errors()
+    }
+
+    /// Whether the enum or error lifts variants unknown to the bindings into a fallback value
+    /// instead of failing.
+    pub fn non_exhaustive_fallback(&self, enum_name: &str) -> bool {
+        match &self.non_exhaustive_fallback {
+            NonExhaustiveFallback::All(all) => *all,
+            NonExhaustiveFallback::Enums(names) => names.contains(enum_name),
+        }
+    }
+
+    /// Whether strings that are not valid UTF-8 fail to lower with a `*LowerError`.
+    pub fn validate_utf8(&self) -> bool {
+        self.string_utf8 == StringUtf8::Validate
+    }
+
+    /// Whether invalid UTF-8 sequences in strings are replaced with U+FFFD when lowered.
+    pub fn replace_invalid_utf8(&self) -> bool {
+        self.string_utf8 == StringUtf8::Replace
+    }
+
+    /// Whether the library is checked and initialized by an exported `Initialize() error`, called
+    /// lazily by every entry point, instead of in `init()`.
+    pub fn deferred_initialization(&self) -> bool {
+        self.deferred_initialization
+    }
+
+    /// Whether any function returns a borrowed view of a Rust buffer.
+    pub fn has_borrowed_results(&self) -> bool {
+        !self.borrowed_results.is_empty()
+    }
+
+    /// `String` or `Bytes` when the function with the given FFI symbol returns a borrowed view,
+    /// configured as `function` or `Object.method` in `borrowed_results`.
+    pub fn borrowed_result(&self, ci: &ComponentInterface, ffi_func_name: &str) -> Option<&str> {
+        self.borrowed_results
+            .iter()
+            .filter_map(|name| borrowed_callable(ci, name).ok())
+            .find(|(name, _)| name == ffi_func_name)
+            .map(|(_, kind)| kind)
+    }
+
+    /// Whether a `<namespace>_fuzz_test.go` file with fuzz targets for the converters is generated.
+    pub fn fuzz_tests(&self) -> bool {
+        self.fuzz_tests
+    }
+
+    /// Whether records, enums and errors implement `slog.LogValuer`.
+    pub fn log_valuer(&self) -> bool {
+        self.log_valuer
+    }
+
+    /// Whether the record field is redacted from logs, configured as `Record.field`.
+    pub fn sensitive_field(&self, type_name: &str, field: &str) -> bool {
+        self.sensitive_fields
+            .contains(&format!("{type_name}.{field}"))
+    }
+
+    /// Whether the enum or error variant field is redacted from logs, configured as
+    /// `Enum.Variant.field`.
+    pub fn sensitive_variant_field(&self, type_name: &str, variant: &str, field: &str) -> bool {
+        self.sensitive_fields
+            .contains(&format!("{type_name}.{variant}.{field}"))
+    }
+
+    /// Stream adapters to generate for the given object, if any.
+    pub fn stream(&self, object_name: &str) -> Option<&StreamConfig> {
+        self.streams.get(object_name)
+    }
+
+    /// Check that functions returning borrowed views exist and return a string or bytes.
+    fn validate_borrowed_results(&self, ci: &ComponentInterface) -> Result<()> {
+        for name in &self.borrowed_results {
+            borrowed_callable(ci, name)?;
+        }
+        Ok(())
+    }
+
+    /// Check that sensitive fields refer to existing record fields or variant fields.
+    fn validate_sensitive_fields(&self, ci: &ComponentInterface) -> Result<()> {
+        for name in &self.sensitive_fields {
+            let exists = match name.split('.').collect::<Vec<_>>()[..] {
+                [record_name, field_name] => ci
+                    .get_record_definition(record_name)
+                    .with_context(|| format!("sensitive field `{name}` is not in a record"))?
+                    .fields()
+                    .iter()
+                    .any(|f| f.name() == field_name),
+                [enum_name, variant_name, field_name] => ci
+                    .get_enum_definition(enum_name)
+                    .with_context(|| format!("sensitive field `{name}` is not in an enum"))?
+                    .variants()
+                    .iter()
+                    .find(|v| v.name() == variant_name)
+                    .with_context(|| {
+                        format!("sensitive field `{name}` has no variant `{variant_name}`")
+                    })?
+                    .fields()
+                    .iter()
+                    .any(|f| f.name() == field_name),
+                _ => anyhow::bail!(
+                    "sensitive field `{name}` must be given as `Record.field` or `Enum.Variant.field`"
+                ),
+            };
+            if !exists {
+                anyhow::bail!("sensitive field `{name}` does not exist");
+            }
+        }
+        Ok(())
+    }
+
+    /// Check that configured streams refer to objects with a suitable method.
+    fn validate_streams(&self, ci: &ComponentInterface) -> Result<()> {
+        for (object_name, stream) in &self.streams {
+            let obj = ci
+                .get_object_definition(object_name)
+                .with_context(|| format!("stream `{object_name}` is not an object"))?;
+
+            let methods = obj.methods();
+            let method = methods
+                .iter()
+                .find(|m| m.name() == stream.method())
+                .with_context(|| {
+                    format!("stream `{object_name}` has no method `{}`", stream.method())
+                })?;
+
+            if !method.is_async()
+                || !method.arguments().is_empty()
+                || !matches!(method.return_type(), Some(Type::Optional { .. }))
+            {
+                anyhow::bail!(
+                    "stream `{object_name}` method `{}` must be `async` with no arguments and return an `Option`",
+                    method.name()
+                );
+            }
+
+            if let Some(clash) = methods
+                .iter()
+                .find(|m| matches!(oracle().fn_name(m.name()).as_str(), "All" | "Chan"))
+            {
+                anyhow::bail!(
+                    "stream `{object_name}` method `{}` clashes with the generated stream adapters",
+                    clash.name()
+                );
+            }
+        }
+        Ok(())
+    }
 }
 
 #[derive(Template)]
@@ -207,7 +484,41 @@ impl<'a> GoWrapper<'a> {
     pub fn new(config: Config, ci: &'a ComponentInterface) -> Self {
         let type_renderer = TypeRenderer::new(&config, ci);
         let type_helper_code = type_renderer.render().expect("type rendering");
-        let type_imports = type_renderer.imports.into_inner();
+        let mut type_imports = type_renderer.imports.into_inner();
+        // Used by `ContractMismatchError` in `wrapper.go`
+        type_imports.insert(ImportRequirement::Module {
+            mod_name: "strings".to_owned(),
+        });
+        // Used for floats in `BinaryRead.go` and `BinaryWrite.go`
+        type_imports.insert(ImportRequirement::Module {
+            mod_name: "math".to_owned(),
+        });
+        // Used to track the object handles written by `LowerIntoRustBuffer`, and by
+        // `deferred_initialization`
+        type_imports.insert(ImportRequirement::Module {
+            mod_name: "sync".to_owned(),
+        });
+        // Used to unwrap the cause of a `*LowerError` in `Helpers.go`
+        type_imports.insert(ImportRequirement::Module {
+            mod_name: "errors".to_owned(),
+        });
+        if config.has_borrowed_results() {
+            // Used by the borrowed views in `Helpers.go`
+            type_imports.insert(ImportRequirement::Module {
+                mod_name: "sync/atomic".to_owned(),
+            });
+        }
+        if ci.has_async_fns() {
+            // Used by the async runtime in `Async.go`, merged here so that they are de-duped
+            // with the imports of the type templates
+            type_imports.extend(
+                ["context", "runtime/cgo", "sync", "sync/atomic"].map(|name| {
+                    ImportRequirement::Module {
+                        mod_name: name.to_owned(),
+                    }
+                }),
+            );
+        }
         Self {
             config,
             ci,
@@ -233,16 +544,346 @@ impl<'a> GoWrapper<'a> {
             )
             .collect()
     }
+
+    /// Every FFI checksum paired with the Go API item it guards, so that the bindings can say
+    /// which function, constructor or method is out of sync with the Rust library.
+    pub fn checksum_items(&self) -> Vec<ChecksumItem> {
+        checksum_items(self.ci)
+    }
+}
+
+/// Methods of records and enums are reported under the Go type of the record or enum.
+fn checksum_items(ci: &ComponentInterface) -> Vec<ChecksumItem> {
+    let mut items = HashMap::new();
+    for func in ci.function_definitions() {
+        items.insert(
+            func.checksum_fn_name(),
+            (
+                "function",
+                String::new(),
+                oracle().fn_name(func.name()),
+                go_signature(func, ci),
+            ),
+        );
+    }
+    for obj in ci.object_definitions() {
+        let (_, impl_name) = oracle().object_names(obj);
+        for cons in obj.constructors() {
+            let name = if cons.is_primary_constructor() {
+                format!("New{impl_name}")
+            } else {
+                format!("{impl_name}{}", oracle().fn_name(cons.name()))
+            };
+            items.insert(
+                cons.checksum_fn_name(),
+                (
+                    "constructor",
+                    impl_name.clone(),
+                    name,
+                    go_signature(cons, ci),
+                ),
+            );
+        }
+        for meth in obj.methods() {
+            items.insert(
+                meth.checksum_fn_name(),
+                (
+                    "method",
+                    impl_name.clone(),
+                    oracle().fn_name(meth.name()),
+                    go_signature(meth, ci),
+                ),
+            );
+        }
+    }
+    for rec in ci.record_definitions() {
+        let rec_name = oracle().class_name(rec.name());
+        for meth in rec.methods() {
+            items.insert(
+                meth.checksum_fn_name(),
+                (
+                    "method",
+                    rec_name.clone(),
+                    oracle().fn_name(meth.name()),
+                    go_signature(meth, ci),
+                ),
+            );
+        }
+    }
+    for e in ci.enum_definitions() {
+        let enum_name = oracle().class_name(e.name());
+        for meth in e.methods() {
+            items.insert(
+                meth.checksum_fn_name(),
+                (
+                    "method",
+                    enum_name.clone(),
+                    oracle().fn_name(meth.name()),
+                    go_signature(meth, ci),
+                ),
+            );
+        }
+    }
+    for cbi in ci.callback_interface_definitions() {
+        let cbi_name = oracle().class_name(cbi.name());
+        for meth in cbi.methods() {
+            items.insert(
+                meth.checksum_fn_name(),
+                (
+                    "callback method",
+                    cbi_name.clone(),
+                    oracle().fn_name(meth.name()),
+                    go_signature(meth, ci),
+                ),
+            );
+        }
+    }
+
+    ci.iter_checksums()
+        .map(|(symbol, checksum)| {
+            // Anything not described above is still reported by its symbol
+            let (kind, object, name, signature) = items.remove(&symbol).unwrap_or((
+                "item",
+                String::new(),
+                symbol.clone(),
+                String::new(),
+            ));
+            ChecksumItem {
+                symbol,
+                checksum,
+                kind,
+                object,
+                name,
+                signature,
+            }
+        })
+        .collect()
+}
+
+/// Go API item guarded by an FFI checksum
+pub struct ChecksumItem {
+    pub symbol: String,
+    pub checksum: u16,
+    pub kind: &'static str,
+    /// Go type of constructors and methods, empty for functions
+    pub object: String,
+    pub name: String,
+    /// Arguments and results in Go types, e.g. `(by uint32) (uint32, error)`
+    pub signature: String,
+}
+
+fn go_signature(callable: &impl Callable, ci: &ComponentInterface) -> String {
+    let args = callable
+        .arguments()
+        .into_iter()
+        .map(|arg| {
+            format!(
+                "{} {}",
+                oracle().var_name(arg.name()),
+                oracle().find(arg, ci).type_label(ci)
+            )
+        })
+        .collect::<Vec<_>>()
+        .join(", ");
+    let return_type = callable
+        .return_type()
+        .map(|t| oracle().find(&t, ci).type_label(ci));
+    let results = match (return_type, callable.throws_type().is_some()) {
+        (Some(t), true) => format!(" ({t}, error)"),
+        (Some(t), false) => format!(" {t}"),
+        (None, true) => " error".to_owned(),
+        (None, false) => String::new(),
+    };
+    format!("({args}){results}")
+}
+
+/// Resolves a `borrowed_results` entry to its FFI symbol and the kind of view it returns.
+fn borrowed_callable(ci: &ComponentInterface, name: &str) -> Result<(String, &'static str)> {
+    let (ffi_func_name, return_type, is_async) = match name.split_once('.') {
+        None => {
+            let func = ci
+                .get_function_definition(name)
+                .with_context(|| format!("borrowed result `{name}` is not a function"))?;
+            (
+                func.ffi_func().name().to_owned(),
+                func.return_type().cloned(),
+                func.is_async(),
+            )
+        }
+        Some((object_name, method_name)) => {
+            let obj = ci
+                .get_object_definition(object_name)
+                .with_context(|| format!("borrowed result `{name}` is not an object method"))?;
+            if obj.has_callback_interface() {
+                anyhow::bail!(
+                    "borrowed result `{name}` is a method of a trait interface implementable in Go"
+                );
+            }
+            let method = obj
+                .methods()
+                .into_iter()
+                .find(|m| m.name() == method_name)
+                .with_context(|| format!("object `{object_name}` has no method `{method_name}`"))?;
+            (
+                method.ffi_func().name().to_owned(),
+                method.return_type().cloned(),
+                method.is_async(),
+            )
+        }
+    };
+    if is_async {
+        anyhow::bail!("borrowed result `{name}` must not be `async`");
+    }
+    match return_type {
+        Some(Type::String) => Ok((ffi_func_name, "String")),
+        Some(Type::Bytes) => Ok((ffi_func_name, "Bytes")),
+        _ => anyhow::bail!("borrowed result `{name}` must return a string or bytes"),
+    }
 }
 
-pub fn generate_go_bindings(config: &Config, ci: &ComponentInterface) -> Result<(String, String)> {
+/// Renders the bridging header, the Go bindings and, with `fuzz_tests`, their fuzz tests.
+pub fn generate_go_bindings(
+    config: &Config,
+    ci: &ComponentInterface,
+) -> Result<(String, String, Option<String>)> {
+    config.validate_streams(ci)?;
+    config.validate_borrowed_results(ci)?;
+    config.validate_sensitive_fields(ci)?;
+    validate_future_name(ci)?;
     let header = BridgingHeader::new(config, ci)
         .render()
         .context("failed to render Go bridging header")?;
     let wrapper = GoWrapper::new(config.clone(), ci)
         .render()
         .context("failed to render go bindings")?;
-    Ok((header, wrapper))
+    let fuzz_tests = if config.fuzz_tests() {
+        let fuzz_tests = FuzzTests::new(config, ci)
+            .render()
+            .context("failed to render go fuzz tests")?;
+        Some(fuzz_tests)
+    } else {
+        None
+    };
+    Ok((header, wrapper, fuzz_tests))
+}
+
+/// Check that no type or function takes the name of the `Future[T]` handle returned by async
+/// `...Future` variants.
+fn validate_future_name(ci: &ComponentInterface) -> Result<()> {
+    if !ci.has_async_fns() {
+        return Ok(());
+    }
+    for type_ in ci.iter_local_types() {
+        let name = match type_ {
+            Type::Record { name, .. }
+            | Type::Enum { name, .. }
+            | Type::Object { name, .. }
+            | Type::CallbackInterface { name, .. }
+            | Type::Custom { name, .. } => name,
+            _ => continue,
+        };
+        if oracle().class_name(name) == "Future" {
+            anyhow::bail!(
+                "type `{name}` collides with the `Future` type of async bindings, rename it"
+            );
+        }
+    }
+    for func in ci.function_definitions() {
+        if oracle().fn_name(func.name()) == "Future" {
+            anyhow::bail!(
+                "function `{}` collides with the `Future` type of async bindings, rename it",
+                func.name()
+            );
+        }
+    }
+    Ok(())
+}
+
+/// Template for the `<namespace>_fuzz_test.go` file, with a fuzz target per converter checking
+/// that reading arbitrary bytes only fails with a `*LiftError`, and that values read survive
+/// being written and read back.
+#[derive(Template)]
+#[template(syntax = "go", escape = "none", path = "FuzzTestTemplate.go")]
+pub struct FuzzTests<'config, 'ci> {
+    config: &'config Config,
+    ci: &'ci ComponentInterface,
+}
+
+/// Converter exercised by a fuzz target
+pub struct FuzzTarget {
+    /// Suffix of the fuzz target name
+    pub name: String,
+    pub converter: String,
+    /// Whether written values can be read back, flat errors are written without their message
+    pub round_trip: bool,
+}
+
+impl<'config, 'ci> FuzzTests<'config, 'ci> {
+    pub fn new(config: &'config Config, ci: &'ci ComponentInterface) -> Self {
+        Self { config, ci }
+    }
+
+    pub fn targets(&self) -> Vec<FuzzTarget> {
+        let ci = self.ci;
+        ci.iter_local_types()
+            .filter(|t| self.fuzzable(t, &mut HashSet::new()))
+            .map(|t| {
+                let code_type = oracle().find(t, ci);
+                let flat_error = match t {
+                    Type::Enum { name, .. } => {
+                        ci.is_name_used_as_error(name)
+                            && ci.get_enum_definition(name).is_some_and(|e| e.is_flat())
+                    }
+                    _ => false,
+                };
+                FuzzTarget {
+                    name: code_type.canonical_name(),
+                    converter: code_type.ffi_converter_instance(),
+                    round_trip: !flat_error,
+                }
+            })
+            .collect()
+    }
+
+    /// Whether values of the type can be read from arbitrary bytes. Objects and callback
+    /// interfaces are read as handles, which must come from Rust, external types are fuzzed in
+    /// their own package.
+    fn fuzzable(&self, type_: &Type, seen: &mut HashSet<String>) -> bool {
+        let ci = self.ci;
+        if ci.is_external(type_) {
+            return false;
+        }
+        match type_ {
+            Type::Object { .. } | Type::CallbackInterface { .. } => false,
+            Type::Optional { inner_type } | Type::Sequence { inner_type } => {
+                self.fuzzable(inner_type, seen)
+            }
+            Type::Map {
+                key_type,
+                value_type,
+            } => self.fuzzable(key_type, seen) && self.fuzzable(value_type, seen),
+            Type::Custom { builtin, .. } => self.fuzzable(builtin, seen),
+            Type::Record { name, .. } => {
+                // Recursive types are decided by their outermost use
+                if !seen.insert(name.clone()) {
+                    return true;
+                }
+                ci.get_record_definition(name)
+                    .is_some_and(|r| r.fields().iter().all(|f| self.fuzzable(&f.as_type(), seen)))
+            }
+            Type::Enum { name, .. } => {
+                if !seen.insert(name.clone()) {
+                    return true;
+                }
+                ci.get_enum_definition(name).is_some_and(|e| {
+                    e.variants()
+                        .iter()
+                        .all(|v| v.fields().iter().all(|f| self.fuzzable(&f.as_type(), seen)))
+                })
+            }
+            _ => true,
+        }
+    }
 }
 
 /// Template for generating the `.h` file that defines the low-level C FFI.
@@ -645,6 +1286,20 @@ impl<'a> TypeRenderer<'a> {
         ""
     }
 
+    /// Go types of the variants of a tagged enum, including the unknown variant fallback.
+    pub fn variant_type_names(&self, e: &Enum) -> Vec<String> {
+        let type_name = oracle().class_name(e.name());
+        let mut names: Vec<String> = e
+            .variants()
+            .iter()
+            .map(|variant| format!("{type_name}{}", oracle().class_name(variant.name())))
+            .collect();
+        if self.config.non_exhaustive_fallback(e.name()) {
+            names.push(format!("{type_name}UnknownVariant"));
+        }
+        names
+    }
+
     pub fn field_type_name(&self, field: &Field, ci: &ComponentInterface) -> String {
         let name = oracle().find(&field.as_type(), ci).type_label(ci);
         match self.ci.is_name_used_as_error(&name) {
@@ -653,3 +1308,168 @@ impl<'a> TypeRenderer<'a> {
         }
     }
 }
+
+#[cfg(test)]
+mod tests {
+    use super::*;
+    use uniffi_meta::{
+        FnParamMetadata, Metadata, MetadataGroup, MethodMetadata, NamespaceMetadata, Type,
+    };
+
+    const UDL: &str = r#"
+        namespace checksums {
+            u32 add(u32 left, u32 right);
+        };
+
+        dictionary Point {
+            i32 x;
+            i32 y;
+        };
+
+        enum Direction {
+            "North",
+            "South",
+        };
+
+        [Enum]
+        interface Shape {
+            Circle(double radius);
+        };
+
+        interface Counter {
+            constructor();
+            [Name=starting_at]
+            constructor(u32 start);
+            void increment(u32 by);
+        };
+
+        callback interface Listener {
+            void notify(string message);
+        };
+    "#;
+
+    // Methods of records and enums can only be exported with proc-macros
+    fn method(self_name: &str, name: &str, inputs: Vec<FnParamMetadata>) -> Metadata {
+        Metadata::Method(MethodMetadata {
+            module_path: "checksums".to_owned(),
+            self_name: self_name.to_owned(),
+            name: name.to_owned(),
+            is_async: false,
+            inputs,
+            return_type: Some(Type::String),
+            throws: None,
+            takes_self_by_arc: false,
+            checksum: Some(42),
+            docstring: None,
+        })
+    }
+
+    fn component_interface() -> ComponentInterface {
+        let mut ci = ComponentInterface::from_webidl(UDL, "checksums").unwrap();
+        ci.add_metadata(MetadataGroup {
+            namespace: NamespaceMetadata {
+                crate_name: "checksums".to_owned(),
+                name: "checksums".to_owned(),
+            },
+            namespace_docstring: None,
+            items: [
+                method(
+                    "Point",
+                    "scaled_description",
+                    vec![FnParamMetadata::simple("factor", Type::Int32)],
+                ),
+                method("Direction", "describe", vec![]),
+            ]
+            .into(),
+        })
+        .unwrap();
+        ci
+    }
+
+    fn find<'a>(items: &'a [ChecksumItem], object: &str, name: &str) -> &'a ChecksumItem {
+        items
+            .iter()
+            .find(|item| item.object == object && item.name == name)
+            .unwrap_or_else(|| panic!("no checksum item for {object}.{name}"))
+    }
+
+    #[test]
+    fn checksum_items_describe_every_callable() {
+        let ci = component_interface();
+        let items = checksum_items(&ci);
+
+        assert_eq!(items.len(), ci.iter_checksums().count());
+        assert!(
+            items.iter().all(|item| item.kind != "item"),
+            "every checksum is described",
+        );
+
+        let add = find(&items, "", "Add");
+        assert_eq!(add.kind, "function");
+        assert_eq!(add.signature, "(left uint32, right uint32) uint32");
+
+        assert_eq!(find(&items, "Counter", "NewCounter").kind, "constructor");
+        assert_eq!(
+            find(&items, "Counter", "CounterStartingAt").kind,
+            "constructor"
+        );
+        assert_eq!(
+            find(&items, "Counter", "Increment").signature,
+            "(by uint32)"
+        );
+        assert_eq!(find(&items, "Listener", "Notify").kind, "callback method");
+
+        let scaled = find(&items, "Point", "ScaledDescription");
+        assert_eq!(scaled.kind, "method");
+        assert_eq!(scaled.signature, "(factor int32) string");
+        assert_eq!(find(&items, "Direction", "Describe").kind, "method");
+    }
+
+    fn validate_sensitive_fields(fields: &[&str]) -> Result<()> {
+        let config = Config {
+            sensitive_fields: fields.iter().map(|f| f.to_string()).collect(),
+            ..Default::default()
+        };
+        config.validate_sensitive_fields(&component_interface())
+    }
+
+    #[test]
+    fn future_name_is_reserved_with_async_functions() {
+        let udl = |definitions: &str| {
+            ComponentInterface::from_webidl(
+                &format!("namespace futures {{ [Async] void wait(); }};\n{definitions}"),
+                "futures",
+            )
+            .unwrap()
+        };
+
+        assert!(validate_future_name(&udl("dictionary Promise { u32 value; };")).is_ok());
+        assert!(validate_future_name(&udl("dictionary Future { u32 value; };")).is_err());
+        assert!(validate_future_name(&udl("interface future { constructor(); };")).is_err());
+
+        let blocking = ComponentInterface::from_webidl(
+            "namespace futures {}; dictionary Future { u32 value; };",
+            "futures",
+        )
+        .unwrap();
+        assert!(validate_future_name(&blocking).is_ok());
+    }
+
+    #[test]
+    fn sensitive_fields_must_exist() {
+        assert!(validate_sensitive_fields(&["Point.x", "Shape.Circle.radius"]).is_ok());
+
+        for missing in [
+            "Point.z",
+            "Counter.x",
+            "Shape.Square.side",
+            "Shape.Circle.side",
+            "x",
+        ] {
+            assert!(
+                validate_sensitive_fields(&[missing]).is_err(),
+                "`{missing}` is rejected",
+            );
+        }
+    }
+}
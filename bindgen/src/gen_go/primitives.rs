@@ -37,7 +37,7 @@ fn render_literal(literal: &Literal, ci: &ComponentInterface) -> String {
 
     match literal {
         Literal::Boolean(v) => format!("{}", v),
-        Literal::String(s) => format!("\"{}\"", s),
+        Literal::String(s) => quote(s),
         Literal::Int(i, radix, type_) => typed_number(
             type_,
             match radix {
@@ -57,12 +57,32 @@ fn render_literal(literal: &Literal, ci: &ComponentInterface) -> String {
             ci,
         ),
         Literal::Float(string, type_) => typed_number(type_, string.clone(), ci),
+        // For bytes
+        Literal::EmptySequence => "[]byte{}".into(),
         _ => unreachable!("Literal"),
     }
 }
 
+/// Quote a string as a Go interpreted string literal.
+fn quote(s: &str) -> String {
+    let mut quoted = String::from("\"");
+    for c in s.chars() {
+        match c {
+            '"' => quoted.push_str("\\\""),
+            '\\' => quoted.push_str("\\\\"),
+            '\n' => quoted.push_str("\\n"),
+            '\r' => quoted.push_str("\\r"),
+            '\t' => quoted.push_str("\\t"),
+            c if c.is_control() => quoted.push_str(&format!("\\u{:04x}", c as u32)),
+            c => quoted.push(c),
+        }
+    }
+    quoted.push('"');
+    quoted
+}
+
 macro_rules! impl_code_type_for_primitive {
-    ($T:ty, $class_name:literal, $canonical_name:literal) => {
+    ($T:ty, $class_name:literal, $canonical_name:literal, $default:literal) => {
         paste! {
             #[derive(Debug)]
             pub struct $T;
@@ -80,21 +100,25 @@ macro_rules! impl_code_type_for_primitive {
                 fn literal(&self, literal: &Literal, ci: &ComponentInterface) -> String {
                     render_literal(&literal, ci)
                 }
+
+                fn default_literal(&self, _ci: &ComponentInterface) -> String {
+                    $default.into()
+                }
             }
         }
     };
 }
 
-impl_code_type_for_primitive!(BooleanCodeType, "bool", "Bool");
-impl_code_type_for_primitive!(StringCodeType, "string", "String");
-impl_code_type_for_primitive!(Int8CodeType, "int8", "Int8");
-impl_code_type_for_primitive!(Int16CodeType, "int16", "Int16");
-impl_code_type_for_primitive!(Int32CodeType, "int32", "Int32");
-impl_code_type_for_primitive!(Int64CodeType, "int64", "Int64");
-impl_code_type_for_primitive!(UInt8CodeType, "uint8", "Uint8");
-impl_code_type_for_primitive!(UInt16CodeType, "uint16", "Uint16");
-impl_code_type_for_primitive!(UInt32CodeType, "uint32", "Uint32");
-impl_code_type_for_primitive!(UInt64CodeType, "uint64", "Uint64");
-impl_code_type_for_primitive!(Float32CodeType, "float32", "Float32");
-impl_code_type_for_primitive!(Float64CodeType, "float64", "Float64");
-impl_code_type_for_primitive!(BytesCodeType, "[]byte", "Bytes");
+impl_code_type_for_primitive!(BooleanCodeType, "bool", "Bool", "false");
+impl_code_type_for_primitive!(StringCodeType, "string", "String", "\"\"");
+impl_code_type_for_primitive!(Int8CodeType, "int8", "Int8", "0");
+impl_code_type_for_primitive!(Int16CodeType, "int16", "Int16", "0");
+impl_code_type_for_primitive!(Int32CodeType, "int32", "Int32", "0");
+impl_code_type_for_primitive!(Int64CodeType, "int64", "Int64", "0");
+impl_code_type_for_primitive!(UInt8CodeType, "uint8", "Uint8", "0");
+impl_code_type_for_primitive!(UInt16CodeType, "uint16", "Uint16", "0");
+impl_code_type_for_primitive!(UInt32CodeType, "uint32", "Uint32", "0");
+impl_code_type_for_primitive!(UInt64CodeType, "uint64", "Uint64", "0");
+impl_code_type_for_primitive!(Float32CodeType, "float32", "Float32", "0");
+impl_code_type_for_primitive!(Float64CodeType, "float64", "Float64", "0");
+impl_code_type_for_primitive!(BytesCodeType, "[]byte", "Bytes", "[]byte{}");
@@ -29,4 +29,17 @@ impl CodeType for RecordCodeType {
     fn literal(&self, _literal: &Literal, _ci: &ComponentInterface) -> String {
         unreachable!();
     }
+
+    fn default_literal(&self, ci: &ComponentInterface) -> String {
+        let rec = ci
+            .get_record_definition(&self.name)
+            .expect("missing record");
+        if rec.fields().iter().any(|f| f.default_value().is_none()) {
+            panic!(
+                "{} can only be defaulted if all of its fields have defaults",
+                self.name
+            );
+        }
+        format!("New{}Default()", self.canonical_name())
+    }
 }
@@ -94,14 +94,21 @@ impl uniffi_bindgen::BindingGenerator for BindingGeneratorGo {
             let bindings_path = full_bindings_path(config, &settings.out_dir);
             fs::create_dir_all(&bindings_path)?;
             let go_file = bindings_path.join(format!("{}.go", ci.namespace()));
-            let (header, wrapper) = generate_go_bindings(&config, &ci)?;
+            let (header, wrapper, fuzz_tests) = generate_go_bindings(&config, &ci)?;
             fs::write(&go_file, wrapper)?;
 
+            let mut go_files = vec![go_file.clone()];
+            if let Some(fuzz_tests) = fuzz_tests {
+                let fuzz_file = bindings_path.join(format!("{}_fuzz_test.go", ci.namespace()));
+                fs::write(&fuzz_file, fuzz_tests)?;
+                go_files.push(fuzz_file);
+            }
+
             let header_file = bindings_path.join(config.header_filename());
             fs::write(header_file, header)?;
 
             if settings.try_format_code {
-                match Command::new("go").arg("fmt").arg(&go_file).output() {
+                match Command::new("go").arg("fmt").args(&go_files).output() {
                     Ok(out) => {
                         if !out.status.success() {
                             let msg = match String::from_utf8(out.stderr) {
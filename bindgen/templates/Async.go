@@ -9,13 +9,85 @@ const (
 
 type rustFuturePollFunc func(C.uint64_t, C.UniffiRustFutureContinuationCallback, C.uint64_t)
 type rustFutureCompleteFunc[T any] func(C.uint64_t, *C.RustCallStatus) T
+type rustFutureCancelFunc func(C.uint64_t)
 type rustFutureFreeFunc func(C.uint64_t)
 
 //export {{ config|future_continuation_name }}
 func {{ config|future_continuation_name }}(data C.uint64_t, pollResult C.int8_t) {
-	h := cgo.Handle(uintptr(data))
-	waiter := h.Value().(chan int8)
-	waiter <- int8(pollResult)
+	if continuation, ok := uniffiContinuations.get(uint64(data)); ok {
+		continuation.resume(int8(pollResult))
+	}
+}
+
+// Receives the poll result of a Rust future when it is woken up. Implementations must not
+// block, nor poll the Rust future again from within resume.
+type uniffiContinuation interface {
+	resume(pollResult int8)
+}
+
+// Continuations are handed to Rust as plain integer IDs. The registry is sharded so that
+// concurrent async calls rarely contend on the same lock.
+const uniffiContinuationShardCount = 16
+
+type uniffiContinuationShard struct {
+	mu            sync.Mutex
+	continuations map[uint64]uniffiContinuation
+}
+
+type uniffiContinuationRegistry struct {
+	nextId atomic.Uint64
+	shards [uniffiContinuationShardCount]uniffiContinuationShard
+}
+
+var uniffiContinuations = newUniffiContinuationRegistry()
+
+func newUniffiContinuationRegistry() *uniffiContinuationRegistry {
+	registry := &uniffiContinuationRegistry{}
+	for i := range registry.shards {
+		registry.shards[i].continuations = make(map[uint64]uniffiContinuation)
+	}
+	return registry
+}
+
+func (r *uniffiContinuationRegistry) shard(id uint64) *uniffiContinuationShard {
+	return &r.shards[id%uniffiContinuationShardCount]
+}
+
+func (r *uniffiContinuationRegistry) insert(continuation uniffiContinuation) uint64 {
+	id := r.nextId.Add(1)
+	shard := r.shard(id)
+	shard.mu.Lock()
+	shard.continuations[id] = continuation
+	shard.mu.Unlock()
+	return id
+}
+
+func (r *uniffiContinuationRegistry) get(id uint64) (uniffiContinuation, bool) {
+	shard := r.shard(id)
+	shard.mu.Lock()
+	continuation, ok := shard.continuations[id]
+	shard.mu.Unlock()
+	return continuation, ok
+}
+
+func (r *uniffiContinuationRegistry) remove(id uint64) {
+	shard := r.shard(id)
+	shard.mu.Lock()
+	delete(shard.continuations, id)
+	shard.mu.Unlock()
+}
+
+// Continuation of a blocking async call, waiters are reused across calls
+type uniffiWaiter chan int8
+
+func (w uniffiWaiter) resume(pollResult int8) {
+	w <- pollResult
+}
+
+var uniffiWaiterPool = sync.Pool{
+	New: func() any {
+		return uniffiWaiter(make(chan int8, 1))
+	},
 }
 
 func uniffiRustCallAsync[E any, T any, F any](
@@ -26,31 +98,282 @@ func uniffiRustCallAsync[E any, T any, F any](
 	pollFunc rustFuturePollFunc,
 	freeFunc rustFutureFreeFunc,
 ) (T, E) {
+	// Background context is never done, so the future is never cancelled
+	goValue, err, _ := uniffiRustCallAsyncCtx(
+		context.Background(),
+		errConverter,
+		completeFunc,
+		liftFunc,
+		rustFuture,
+		pollFunc,
+		nil,
+		freeFunc,
+	)
+	return goValue, err
+}
+
+// Same as uniffiRustCallAsync, but gives up on the Rust future once ctx is done.
+// In that case the future is cancelled on the Rust side and the wrapped context
+// error is returned as the last value.
+func uniffiRustCallAsyncCtx[E any, T any, F any](
+	ctx context.Context,
+	errConverter BufReader[E],
+	completeFunc rustFutureCompleteFunc[F],
+	liftFunc func(F) T,
+	rustFuture C.uint64_t,
+	pollFunc rustFuturePollFunc,
+	cancelFunc rustFutureCancelFunc,
+	freeFunc rustFutureFreeFunc,
+) (T, E, error) {
 	defer freeFunc(rustFuture)
 
+	var goValue T
+	var goErr E
+
 	pollResult := int8(-1)
-	waiter := make(chan int8, 1)
+	// Every poll is matched by exactly one resume, so the waiter is always drained
+	// by the time it goes back to the pool
+	waiter := uniffiWaiterPool.Get().(uniffiWaiter)
+	defer uniffiWaiterPool.Put(waiter)
 
-	chanHandle := cgo.NewHandle(waiter)
-	defer chanHandle.Delete()
+	id := uniffiContinuations.insert(waiter)
+	defer uniffiContinuations.remove(id)
 
 	for pollResult != uniffiRustFuturePollReady {
 		pollFunc(
 			rustFuture,
 			(C.UniffiRustFutureContinuationCallback)(C.{{ config|future_continuation_name }}),
-			C.uint64_t(chanHandle),
+			C.uint64_t(id),
 		)
-		pollResult = <-waiter
+		select {
+		case pollResult = <-waiter:
+		case <-ctx.Done():
+			// Cancelling wakes up the pending continuation with a ready result, wait for it
+			// so that the handle is not deleted while Rust still holds onto it.
+			cancelFunc(rustFuture)
+			<-waiter
+			return goValue, goErr, fmt.Errorf("rust future cancelled: %w", ctx.Err())
+		}
 	}
 
-	var goValue T
-	ffiValue, err := rustCallWithError(errConverter, func(status *C.RustCallStatus) F {
-		return completeFunc(rustFuture, status)
-	})
-	if value := reflect.ValueOf(err); value.IsValid() && !value.IsZero() {
-		return goValue, err
+	var status C.RustCallStatus
+	ffiValue := completeFunc(rustFuture, &status)
+	if status.code != 0 {
+		return goValue, checkCallStatus(errConverter, status), nil
+	}
+	return liftFunc(ffiValue), goErr, nil
+}
+
+// Future is a handle to an async Rust call started by one of the generated `...Future`
+// functions. The call makes progress in the background without blocking the caller.
+type Future[T any] struct {
+	done  chan struct{}
+	value T
+	err   error
+
+	// Guards everything below, the Rust future must not be polled and cancelled at once
+	mu        sync.Mutex
+	cancelled bool
+	finished  bool
+
+	rustFuture C.uint64_t
+	id         uint64
+	pollFunc   rustFuturePollFunc
+	cancelFunc rustFutureCancelFunc
+	freeFunc   rustFutureFreeFunc
+	complete   func() (T, error)
+}
+
+// Done returns a channel that is closed once the result is available.
+func (f *Future[T]) Done() <-chan struct{} {
+	return f.done
+}
+
+// Result blocks until the call has finished and returns its result.
+func (f *Future[T]) Result() (T, error) {
+	<-f.done
+	return f.value, f.err
+}
+
+// Wait is the same as Result, but stops waiting once ctx is done. The call itself keeps
+// running, use Cancel to stop it.
+func (f *Future[T]) Wait(ctx context.Context) (T, error) {
+	select {
+	case <-f.done:
+		return f.value, f.err
+	case <-ctx.Done():
+		var zero T
+		return zero, ctx.Err()
+	}
+}
+
+// Cancel cancels the underlying Rust future. The result then reports an error wrapping
+// context.Canceled, unless the call has already finished.
+func (f *Future[T]) Cancel() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.cancelled || f.finished {
+		return
+	}
+	f.cancelled = true
+	// Rust wakes up the pending continuation, which then finishes the future
+	f.cancelFunc(f.rustFuture)
+}
+
+func newFuture[E any, T any, F any](
+	errConverter BufReader[E],
+	completeFunc rustFutureCompleteFunc[F],
+	liftFunc func(F) T,
+	rustFuture C.uint64_t,
+	pollFunc rustFuturePollFunc,
+	cancelFunc rustFutureCancelFunc,
+	freeFunc rustFutureFreeFunc,
+) *Future[T] {
+	f := &Future[T]{
+		done:       make(chan struct{}),
+		rustFuture: rustFuture,
+		pollFunc:   pollFunc,
+		cancelFunc: cancelFunc,
+		freeFunc:   freeFunc,
+	}
+	f.complete = func() (T, error) {
+		var goValue T
+		var status C.RustCallStatus
+		ffiValue := completeFunc(rustFuture, &status)
+		if status.code != 0 {
+			return goValue, any(checkCallStatus(errConverter, status)).(error)
+		}
+		return liftFunc(ffiValue), nil
+	}
+	f.id = uniffiContinuations.insert(f)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.poll()
+	return f
+}
+
+// Future for a call that failed before it reached Rust
+func newFailedFuture[T any](err error) *Future[T] {
+	f := &Future[T]{
+		done:     make(chan struct{}),
+		err:      err,
+		finished: true,
+	}
+	close(f.done)
+	return f
+}
+
+func (f *Future[T]) poll() {
+	f.pollFunc(
+		f.rustFuture,
+		(C.UniffiRustFutureContinuationCallback)(C.{{ config|future_continuation_name }}),
+		C.uint64_t(f.id),
+	)
+}
+
+func (f *Future[T]) resume(pollResult int8) {
+	uniffiFutureDriver.schedule(f, pollResult)
+}
+
+func (f *Future[T]) step(pollResult int8) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	switch {
+	case f.cancelled:
+		var zero T
+		f.finished = true
+		f.finish(zero, fmt.Errorf("rust future cancelled: %w", context.Canceled))
+	case pollResult != uniffiRustFuturePollReady:
+		f.poll()
+	default:
+		// Lifting the result can take a while, so it runs off the driver goroutine to not hold
+		// up other futures. The future is finished from here on, and can no longer be cancelled.
+		f.finished = true
+		go func() {
+			f.finish(f.completeRecover())
+		}()
+	}
+}
+
+// Panics would otherwise bring down the driver goroutine, report them as errors instead. A
+// result that can not be lifted is not a panic of the Rust future, its *LiftError is reported
+// as is.
+func (f *Future[T]) completeRecover() (value T, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			switch r := r.(type) {
+			case *LiftError:
+				err = r
+			case error:
+				err = fmt.Errorf("rust future panicked: %w", r)
+			default:
+				err = fmt.Errorf("rust future panicked: %v", r)
+			}
+		}
+	}()
+	return f.complete()
+}
+
+// Callers mark the future as finished beforehand, while holding its lock
+func (f *Future[T]) finish(value T, err error) {
+	f.freeFunc(f.rustFuture)
+	uniffiContinuations.remove(f.id)
+	f.value = value
+	f.err = err
+	close(f.done)
+}
+
+type uniffiFutureTask interface {
+	step(pollResult int8)
+}
+
+type uniffiFutureWakeup struct {
+	task       uniffiFutureTask
+	pollResult int8
+}
+
+// All futures are polled by a single goroutine. Continuations only queue a wakeup, since
+// polling the Rust future again from within the continuation is not allowed. Ready futures
+// are completed on goroutines of their own.
+var uniffiFutureDriver = &uniffiFutureScheduler{
+	signal: make(chan struct{}, 1),
+}
+
+type uniffiFutureScheduler struct {
+	once   sync.Once
+	mu     sync.Mutex
+	queue  []uniffiFutureWakeup
+	signal chan struct{}
+}
+
+func (s *uniffiFutureScheduler) schedule(task uniffiFutureTask, pollResult int8) {
+	s.once.Do(func() { go s.run() })
+
+	s.mu.Lock()
+	s.queue = append(s.queue, uniffiFutureWakeup{task, pollResult})
+	s.mu.Unlock()
+
+	select {
+	case s.signal <- struct{}{}:
+	default:
+	}
+}
+
+func (s *uniffiFutureScheduler) run() {
+	var batch []uniffiFutureWakeup
+	for range s.signal {
+		s.mu.Lock()
+		batch, s.queue = s.queue, batch[:0]
+		s.mu.Unlock()
+
+		for i := range batch {
+			batch[i].task.step(batch[i].pollResult)
+			batch[i] = uniffiFutureWakeup{}
+		}
 	}
-	return liftFunc(ffiValue), err
 }
 
 //export {{ config|free_gorutine_callback }}
@@ -58,6 +381,6 @@ func {{ config|free_gorutine_callback }}(data C.uint64_t) {
 	handle := cgo.Handle(uintptr(data))
 	defer handle.Delete()
 
-	guard := handle.Value().(chan struct{})
-	guard <- struct{}{}
+	cancel := handle.Value().(context.CancelFunc)
+	cancel()
 }
@@ -2,82 +2,93 @@
  * License, v. 2.0. If a copy of the MPL was not distributed with this
  * file, You can obtain one at http://mozilla.org/MPL/2.0/. */#}
 
-func readInt8(reader io.Reader) int8 {
-	var result int8
-	if err := binary.Read(reader, binary.BigEndian, &result); err != nil {
-		panic(err)
+// Primitives are decoded with encoding/binary on a fixed size array, without reflection, so
+// reading them does not allocate
+
+// Reads the next n <= 8 bytes into the front of buffer
+func readFixed(reader *bytes.Reader, buffer *[8]byte, n int) []byte {
+	if reader.Len() < n {
+		panic(&LiftError{Reason: io.ErrUnexpectedEOF.Error()})
 	}
-	return result
+	reader.Read(buffer[:n])
+	return buffer[:n]
 }
 
-func readUint8(reader io.Reader) uint8 {
-	var result uint8
-	if err := binary.Read(reader, binary.BigEndian, &result); err != nil {
-		panic(err)
-	}
-	return result
+func readInt8(reader *bytes.Reader) int8 {
+	return int8(readUint8(reader))
 }
 
-func readInt16(reader io.Reader) int16 {
-	var result int16
-	if err := binary.Read(reader, binary.BigEndian, &result); err != nil {
-		panic(err)
+func readUint8(reader *bytes.Reader) uint8 {
+	result, err := reader.ReadByte()
+	if err != nil {
+		panic(&LiftError{Reason: io.ErrUnexpectedEOF.Error()})
 	}
 	return result
 }
 
-func readUint16(reader io.Reader) uint16 {
-	var result uint16
-	if err := binary.Read(reader, binary.BigEndian, &result); err != nil {
-		panic(err)
-	}
-	return result
+func readInt16(reader *bytes.Reader) int16 {
+	return int16(readUint16(reader))
 }
 
-func readInt32(reader io.Reader) int32 {
-	var result int32
-	if err := binary.Read(reader, binary.BigEndian, &result); err != nil {
-		panic(err)
-	}
-	return result
+func readUint16(reader *bytes.Reader) uint16 {
+	var buffer [8]byte
+	return binary.BigEndian.Uint16(readFixed(reader, &buffer, 2))
 }
 
-func readUint32(reader io.Reader) uint32 {
-	var result uint32
-	if err := binary.Read(reader, binary.BigEndian, &result); err != nil {
-		panic(err)
-	}
-	return result
+func readInt32(reader *bytes.Reader) int32 {
+	return int32(readUint32(reader))
 }
 
-func readInt64(reader io.Reader) int64 {
-	var result int64
-	if err := binary.Read(reader, binary.BigEndian, &result); err != nil {
-		panic(err)
-	}
-	return result
+func readUint32(reader *bytes.Reader) uint32 {
+	var buffer [8]byte
+	return binary.BigEndian.Uint32(readFixed(reader, &buffer, 4))
 }
 
-func readUint64(reader io.Reader) uint64 {
-	var result uint64
-	if err := binary.Read(reader, binary.BigEndian, &result); err != nil {
-		panic(err)
-	}
-	return result
+func readInt64(reader *bytes.Reader) int64 {
+	return int64(readUint64(reader))
+}
+
+func readUint64(reader *bytes.Reader) uint64 {
+	var buffer [8]byte
+	return binary.BigEndian.Uint64(readFixed(reader, &buffer, 8))
+}
+
+func readFloat32(reader *bytes.Reader) float32 {
+	return math.Float32frombits(readUint32(reader))
+}
+
+func readFloat64(reader *bytes.Reader) float64 {
+	return math.Float64frombits(readUint64(reader))
 }
 
-func readFloat32(reader io.Reader) float32 {
-	var result float32
-	if err := binary.Read(reader, binary.BigEndian, &result); err != nil {
-		panic(err)
+// Reads the length prefix of a string, byte slice, sequence or map
+func readLength(reader *bytes.Reader) int32 {
+	length := readInt32(reader)
+	if length < 0 {
+		panic(&LiftError{Reason: fmt.Sprintf("negative length %d", length)})
 	}
-	return result
+	return length
 }
 
-func readFloat64(reader io.Reader) float64 {
-	var result float64
-	if err := binary.Read(reader, binary.BigEndian, &result); err != nil {
-		panic(err)
+// Reads length bytes, failing if the buffer ends before that
+func readBytes(reader *bytes.Reader, length int32) []byte {
+	// Check before allocating, corrupt lengths must not allocate more than the buffer holds
+	if int(length) > reader.Len() {
+		panic(&LiftError{Reason: fmt.Sprintf("length %d exceeds the %d bytes remaining", length, reader.Len())})
 	}
-	return result
+	buffer := make([]byte, length)
+	reader.Read(buffer)
+	return buffer
+}
+
+// Readers that skipped the fields of a variant unknown to the bindings, mapped to the name of
+// the enum or error
+var uniffiSkippedReaders sync.Map
+
+// Skips the rest of the buffer, used for the fields of variants unknown to the bindings. Their
+// length is not known, so only values ending the buffer can be lifted. Reading anything after
+// them fails with a *LiftError naming the unknown variant.
+func skipRemaining(reader *bytes.Reader, typeName string) {
+	uniffiSkippedReaders.Store(reader, typeName)
+	reader.Seek(0, io.SeekEnd)
 }
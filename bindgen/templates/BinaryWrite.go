@@ -2,62 +2,51 @@
  * License, v. 2.0. If a copy of the MPL was not distributed with this
  * file, You can obtain one at http://mozilla.org/MPL/2.0/. */#}
 
-func writeInt8(writer io.Writer, value int8) {
-	if err := binary.Write(writer, binary.BigEndian, value); err != nil {
-		panic(err)
-	}
+// Primitives are encoded with encoding/binary on a fixed size array, without reflection, so
+// writing them only allocates when the buffer grows
+
+func writeInt8(writer *bytes.Buffer, value int8) {
+	writer.WriteByte(byte(value))
 }
 
-func writeUint8(writer io.Writer, value uint8) {
-	if err := binary.Write(writer, binary.BigEndian, value); err != nil {
-		panic(err)
-	}
+func writeUint8(writer *bytes.Buffer, value uint8) {
+	writer.WriteByte(value)
 }
 
-func writeInt16(writer io.Writer, value int16) {
-	if err := binary.Write(writer, binary.BigEndian, value); err != nil {
-		panic(err)
-	}
+func writeInt16(writer *bytes.Buffer, value int16) {
+	writeUint16(writer, uint16(value))
 }
 
-func writeUint16(writer io.Writer, value uint16) {
-	if err := binary.Write(writer, binary.BigEndian, value); err != nil {
-		panic(err)
-	}
+func writeUint16(writer *bytes.Buffer, value uint16) {
+	var buffer [2]byte
+	binary.BigEndian.PutUint16(buffer[:], value)
+	writer.Write(buffer[:])
 }
 
-func writeInt32(writer io.Writer, value int32) {
-	if err := binary.Write(writer, binary.BigEndian, value); err != nil {
-		panic(err)
-	}
+func writeInt32(writer *bytes.Buffer, value int32) {
+	writeUint32(writer, uint32(value))
 }
 
-func writeUint32(writer io.Writer, value uint32) {
-	if err := binary.Write(writer, binary.BigEndian, value); err != nil {
-		panic(err)
-	}
+func writeUint32(writer *bytes.Buffer, value uint32) {
+	var buffer [4]byte
+	binary.BigEndian.PutUint32(buffer[:], value)
+	writer.Write(buffer[:])
 }
 
-func writeInt64(writer io.Writer, value int64) {
-	if err := binary.Write(writer, binary.BigEndian, value); err != nil {
-		panic(err)
-	}
+func writeInt64(writer *bytes.Buffer, value int64) {
+	writeUint64(writer, uint64(value))
 }
 
-func writeUint64(writer io.Writer, value uint64) {
-	if err := binary.Write(writer, binary.BigEndian, value); err != nil {
-		panic(err)
-	}
+func writeUint64(writer *bytes.Buffer, value uint64) {
+	var buffer [8]byte
+	binary.BigEndian.PutUint64(buffer[:], value)
+	writer.Write(buffer[:])
 }
 
-func writeFloat32(writer io.Writer, value float32) {
-	if err := binary.Write(writer, binary.BigEndian, value); err != nil {
-		panic(err)
-	}
+func writeFloat32(writer *bytes.Buffer, value float32) {
+	writeUint32(writer, math.Float32bits(value))
 }
 
-func writeFloat64(writer io.Writer, value float64) {
-	if err := binary.Write(writer, binary.BigEndian, value); err != nil {
-		panic(err)
-	}
+func writeFloat64(writer *bytes.Buffer, value float64) {
+	writeUint64(writer, math.Float64bits(value))
 }
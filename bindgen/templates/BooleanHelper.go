@@ -13,7 +13,7 @@ func ({{ ffi_converter_name }}) Lower(value bool) C.int8_t {
 	return C.int8_t(0)
 }
 
-func ({{ ffi_converter_name }}) Write(writer io.Writer, value bool) {
+func ({{ ffi_converter_name }}) Write(writer *bytes.Buffer, value bool) {
 	if value {
 		writeInt8(writer, 1)
 	} else {
@@ -21,11 +21,15 @@ func ({{ ffi_converter_name }}) Write(writer io.Writer, value bool) {
 	}
 }
 
+func ({{ ffi_converter_name }}) AllocationSize(_ bool) uint64 {
+	return 1
+}
+
 func ({{ ffi_converter_name }}) Lift(value C.int8_t) bool {
 	return value != 0
 }
 
-func ({{ ffi_converter_name }}) Read(reader io.Reader) bool {
+func ({{ ffi_converter_name }}) Read(reader *bytes.Reader) bool {
 	return readInt8(reader) != 0
 }
 
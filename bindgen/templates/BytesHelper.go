@@ -16,36 +16,25 @@ func (c FfiConverterBytes) LowerExternal(value []byte) ExternalCRustBuffer {
 	return RustBufferFromC(c.Lower(value))
 }
 
-func (c FfiConverterBytes) Write(writer io.Writer, value []byte) {
+func (c FfiConverterBytes) Write(writer *bytes.Buffer, value []byte) {
 	if len(value) > math.MaxInt32 {
-		panic("[]byte is too large to fit into Int32")
+		panic(&LowerError{Reason: "[]byte is too large to fit into Int32"})
 	}
 
 	writeInt32(writer, int32(len(value)))
-	write_length, err := writer.Write(value)
-	if err != nil {
-		panic(err)
-	}
-	if write_length != len(value) {
-		panic(fmt.Errorf("bad write length when writing []byte, expected %d, written %d", len(value), write_length))
-	}
+	writer.Write(value)
+}
+
+func (c FfiConverterBytes) AllocationSize(value []byte) uint64 {
+	return 4 + uint64(len(value))
 }
 
 func (c FfiConverterBytes) Lift(rb RustBufferI) []byte {
 	return LiftFromRustBuffer[[]byte](c, rb)
 }
 
-func (c FfiConverterBytes) Read(reader io.Reader) []byte {
-	length := readInt32(reader)
-	buffer := make([]byte, length)
-	read_length, err := reader.Read(buffer)
-	if err != nil && err != io.EOF {
-		panic(err)
-	}
-	if read_length != int(length) {
-		panic(fmt.Errorf("bad read length when reading []byte, expected %d, read %d", length, read_length))
-	}
-	return buffer
+func (c FfiConverterBytes) Read(reader *bytes.Reader) []byte {
+	return readBytes(reader, readLength(reader))
 }
 
 type {{ ffi_destroyer_name }} struct {}
@@ -0,0 +1,155 @@
+{{- self.add_import("errors") }}
+{{- self.add_import("sync") }}
+{{- self.add_import("sync/atomic") }}
+
+// CallbackExecutor runs the Go side of async callback and trait interface methods called
+// from Rust. Synchronous methods always run on the calling Rust thread.
+type CallbackExecutor interface {
+	// Execute runs task, or returns an error if the task can not be accepted. Rejected
+	// calls fail on the Rust side with an unexpected callback error.
+	Execute(task func()) error
+}
+
+// ErrCallbackRejected is returned by BoundedCallbackExecutor when its queue is full.
+var ErrCallbackRejected = errors.New("callback rejected: executor queue is full")
+
+// ErrCallbackExecutorClosed is returned by BoundedCallbackExecutor after Close.
+var ErrCallbackExecutorClosed = errors.New("callback rejected: executor is closed")
+
+var uniffiCallbackExecutor atomic.Pointer[CallbackExecutor]
+
+// SetCallbackExecutor replaces the executor used for async callbacks of this package.
+// Passing nil restores the default UnboundedCallbackExecutor.
+func SetCallbackExecutor(executor CallbackExecutor) {
+	if executor == nil {
+		uniffiCallbackExecutor.Store(nil)
+		return
+	}
+	uniffiCallbackExecutor.Store(&executor)
+}
+
+func currentCallbackExecutor() CallbackExecutor {
+	if executor := uniffiCallbackExecutor.Load(); executor != nil {
+		return *executor
+	}
+	return UnboundedCallbackExecutor{}
+}
+
+// UnboundedCallbackExecutor runs every task on a goroutine of its own. This is the default.
+type UnboundedCallbackExecutor struct{}
+
+func (UnboundedCallbackExecutor) Execute(task func()) error {
+	go task()
+	return nil
+}
+
+// CallbackQueueFullPolicy decides what BoundedCallbackExecutor does when its queue is full.
+type CallbackQueueFullPolicy int
+
+const (
+	// Block the calling Rust thread until there is room in the queue, or until the executor
+	// is closed. Tasks that wait for further async callbacks can exhaust the workers this way.
+	CallbackQueueFullBlock CallbackQueueFullPolicy = iota
+	// Reject the task with ErrCallbackRejected.
+	CallbackQueueFullReject
+	// Run the task on the calling Rust thread.
+	CallbackQueueFullCallerRuns
+)
+
+// BoundedCallbackExecutor runs tasks on a fixed number of worker goroutines.
+type BoundedCallbackExecutor struct {
+	tasks   chan func()
+	policy  CallbackQueueFullPolicy
+	closing chan struct{}
+
+	// Guards closed and the registration of submitters, never held while blocking on the queue
+	lock       sync.Mutex
+	closed     bool
+	submitting sync.WaitGroup
+	workers    sync.WaitGroup
+}
+
+// NewBoundedCallbackExecutor starts workers goroutines that share a queue of queueSize
+// pending tasks.
+func NewBoundedCallbackExecutor(workers int, queueSize int, policy CallbackQueueFullPolicy) *BoundedCallbackExecutor {
+	if workers < 1 {
+		panic(fmt.Errorf("bounded callback executor needs at least one worker, got %d", workers))
+	}
+	if queueSize < 0 {
+		panic(fmt.Errorf("bounded callback executor queue size can not be negative, got %d", queueSize))
+	}
+
+	executor := &BoundedCallbackExecutor{
+		tasks:   make(chan func(), queueSize),
+		policy:  policy,
+		closing: make(chan struct{}),
+	}
+	executor.workers.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer executor.workers.Done()
+			for task := range executor.tasks {
+				task()
+			}
+		}()
+	}
+	return executor
+}
+
+func (e *BoundedCallbackExecutor) Execute(task func()) error {
+	queued, err := e.enqueue(task)
+	if err == nil && !queued {
+		task()
+	}
+	return err
+}
+
+// Returns false if the task should run on the calling thread instead
+func (e *BoundedCallbackExecutor) enqueue(task func()) (bool, error) {
+	e.lock.Lock()
+	if e.closed {
+		e.lock.Unlock()
+		return false, ErrCallbackExecutorClosed
+	}
+	// Close waits for submitters before closing the queue
+	e.submitting.Add(1)
+	e.lock.Unlock()
+	defer e.submitting.Done()
+
+	select {
+	case e.tasks <- task:
+		return true, nil
+	default:
+	}
+
+	switch e.policy {
+	case CallbackQueueFullReject:
+		return false, ErrCallbackRejected
+	case CallbackQueueFullCallerRuns:
+		return false, nil
+	default:
+		select {
+		case e.tasks <- task:
+			return true, nil
+		case <-e.closing:
+			return false, ErrCallbackExecutorClosed
+		}
+	}
+}
+
+// Close stops accepting new tasks and waits for the queued ones to finish. Submitters blocked
+// on a full queue give up with ErrCallbackExecutorClosed. Close waits for the workers, so it
+// must not be called from within a task, which would never return.
+func (e *BoundedCallbackExecutor) Close() {
+	e.lock.Lock()
+	first := !e.closed
+	e.closed = true
+	e.lock.Unlock()
+
+	if first {
+		close(e.closing)
+		e.submitting.Wait()
+		close(e.tasks)
+	}
+	e.workers.Wait()
+}
@@ -1,3 +1,6 @@
+{{- self.add_import("runtime/debug") }}
+{{- self.add_import("strings") }}
+
 type uniffiCallbackResult C.int8_t
 
 const (
@@ -8,3 +11,24 @@ const (
 	uniffiCallbackCancelled             uniffiCallbackResult = 3
 )
 
+
+func uniffiUnexpectedCallbackStatus(message string) C.RustCallStatus {
+	return C.RustCallStatus {
+		code: C.int8_t(uniffiCallbackUnexpectedResultError),
+		// Rust reads the message into a String, which must be valid UTF-8 whatever the Go policy is
+		errorBuf: stringToRustBuffer(strings.ToValidUTF8(message, "\uFFFD")),
+	}
+}
+
+// Callbacks run inside a cgo call from Rust, where a panic would bring down the whole process.
+// Report it to Rust as an unexpected error instead, together with the Go stack.
+func uniffiRecoverCallbackPanic(callStatus *C.RustCallStatus) {
+	if r := recover(); r != nil {
+		// Return values that can not be lowered are not a bug in the callback, the stack is not useful
+		if lowerErr, ok := r.(*LowerError); ok {
+			*callStatus = uniffiUnexpectedCallbackStatus(lowerErr.Error())
+			return
+		}
+		*callStatus = uniffiUnexpectedCallbackStatus(fmt.Sprintf("Go callback panicked: %v\n\n%s", r, debug.Stack()))
+	}
+}
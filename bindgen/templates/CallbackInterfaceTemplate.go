@@ -1,12 +1,13 @@
 {%- let cbi = ci.get_callback_interface_definition(name).expect("missing cbi") %}
 {%- let type_name = cbi|type_name(ci) %}
 {%- let foreign_callback = format!("foreignCallback{}", canonical_type_name) %}
+{%- let takes_ctx = config.takes_context(cbi.name()) %}
 
 {%- call go::docstring(cbi, 0) %}
 type {{ type_name }} interface {
 	{% for meth in cbi.methods() -%}
 	{%- call go::docstring(meth, 1) %}
-	{{ meth.name()|fn_name }}({% call go::arg_list_decl(meth) %}) {% call go::return_type_decl(meth) %}
+	{{ meth.name()|fn_name }}({% call go::interface_arg_list_decl(meth, takes_ctx) %}) {% call go::return_type_decl(meth) %}
 	{% endfor %}
 }
 
@@ -27,7 +28,7 @@ func (c {{ ffi_converter_name }}) Lift(handle uint64) {{ type_name }} {
 	return val
 }
 
-func (c {{ ffi_converter_name }}) Read(reader io.Reader) {{ type_name }} {
+func (c {{ ffi_converter_name }}) Read(reader *bytes.Reader) {{ type_name }} {
 	return c.Lift(readUint64(reader))
 }
 
@@ -35,8 +36,19 @@ func (c {{ ffi_converter_name }}) Lower(value {{ type_name }}) C.uint64_t {
 	return C.uint64_t(c.handleMap.insert(value))
 }
 
-func (c {{ ffi_converter_name }}) Write(writer io.Writer, value {{ type_name }}) {
-	writeUint64(writer, uint64(c.Lower(value)))
+func (c {{ ffi_converter_name }}) Write(writer *bytes.Buffer, value {{ type_name }}) {
+	handle := uint64(c.Lower(value))
+	uniffiTrackWrittenHandle(writer, handle, c.release)
+	writeUint64(writer, handle)
+}
+
+// Releases a lowered handle that never reached Rust
+func (c {{ ffi_converter_name }}) release(handle uint64) {
+	c.handleMap.remove(handle)
+}
+
+func (c {{ ffi_converter_name }}) AllocationSize(_ {{ type_name }}) uint64 {
+	return 8
 }
 
 func LiftFromExternal{{ canonical_type_name }}(handle uint64) {{ type_name }} {
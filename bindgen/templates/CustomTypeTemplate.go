@@ -68,16 +68,21 @@ type {{ ffi_converter_name }} struct{}
 var {{ ffi_converter_instance }} = {{ ffi_converter_name }}{}
 
 func ({{ ffi_converter_name }}) Lower(value {{ name }}) {{ ffi_type_name }} {
-	builtinValue := {{ config.lower("value") }}
+	builtinValue := uniffiLowerCustom("{{ name }}", func() {{ builtin|type_name(ci) }} { return {{ config.lower("value") }} })
 	ffiValue := {{ builtin|lower_fn(ci) }}(builtinValue)
 	return {% call go::remap_ffi_val(builtin, "ffiValue") %}
 }
 
-func ({{ ffi_converter_name }}) Write(writer io.Writer, value {{ name }}) {
-	builtinValue := {{ config.lower("value") }}
+func ({{ ffi_converter_name }}) Write(writer *bytes.Buffer, value {{ name }}) {
+	builtinValue := uniffiLowerCustom("{{ name }}", func() {{ builtin|type_name(ci) }} { return {{ config.lower("value") }} })
 	{{ builtin|write_fn(ci) }}(writer, builtinValue)
 }
 
+func ({{ ffi_converter_name }}) AllocationSize(value {{ name }}) uint64 {
+	builtinValue := uniffiLowerCustom("{{ name }}", func() {{ builtin|type_name(ci) }} { return {{ config.lower("value") }} })
+	return {{ builtin|allocation_size_fn(ci) }}(builtinValue)
+}
+
 func ({{ ffi_converter_name }}) Lift(value {{ ffi_type_name }}) {{ name }} {
 	builtinValue := {{ builtin|lift_fn(ci) }}(value)
 	{{ config.lift("builtinValue") }}
@@ -105,7 +110,7 @@ func LowerToExternal{{ canonical_type_name }}(value {{ name }}) {{ builtin|type_
 {%- endif %}
 {%- endmatch %}
 
-func ({{ ffi_converter_name }}) Read(reader io.Reader) {{ name }} {
+func ({{ ffi_converter_name }}) Read(reader *bytes.Reader) {{ name }} {
 	builtinValue := {{ builtin|read_fn(ci) }}(reader)
 	{{ config.lift("builtinValue") }}
 }
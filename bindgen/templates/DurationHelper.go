@@ -13,9 +13,13 @@ func (c FfiConverterDuration) Lift(rb RustBufferI) time.Duration {
 	return LiftFromRustBuffer[time.Duration](c, rb)
 }
 
-func (c FfiConverterDuration) Read(reader io.Reader) time.Duration {
+func (c FfiConverterDuration) Read(reader *bytes.Reader) time.Duration {
 	sec := readUint64(reader)
 	nsec := readUint32(reader)
+	// time.Duration holds about 292 years, longer Rust durations can not be represented
+	if sec > math.MaxInt64/1_000_000_000 || sec*1_000_000_000+uint64(nsec) > math.MaxInt64 {
+		panic(&LiftError{Reason: fmt.Sprintf("duration of %ds does not fit into time.Duration", sec)})
+	}
 	return time.Duration(sec*1_000_000_000 + uint64(nsec))
 }
 
@@ -27,21 +31,23 @@ func (c FfiConverterDuration) LowerExternal(value time.Duration) ExternalCRustBu
 	return RustBufferFromC(c.Lower(value))
 }
 
-func (c FfiConverterDuration) Write(writer io.Writer, value time.Duration) {
+func (c FfiConverterDuration) Write(writer *bytes.Buffer, value time.Duration) {
 	if value.Nanoseconds() < 0 {
 		// Rust does not support negative durations:
 		// https://www.reddit.com/r/rust/comments/ljl55u/why_rusts_duration_not_supporting_negative_values/
-		// This panic is very bad, because it depends on user input, and in Go user input related
-		// error are supposed to be returned as errors, and not cause panics. However, with the
-		// current architecture, its not possible to return an error from here, so panic is used as
-		// the only other option to signal an error.
-		panic("negative duration is not allowed")
+		// Generated functions recover this panic and return it as a *LowerError before calling Rust.
+		panic(&LowerError{Reason: fmt.Sprintf("negative duration %v is not allowed", value)})
 	}
 
 	writeUint64(writer, uint64(value) / 1_000_000_000)
 	writeUint32(writer, uint32(uint64(value) % 1_000_000_000))
 }
 
+func (c FfiConverterDuration) AllocationSize(_ time.Duration) uint64 {
+	// Seconds as u64 or i64, and nanoseconds as u32
+	return 12
+}
+
 type {{ ffi_destroyer_name }} struct {}
 
 func ({{ ffi_destroyer_name }}) Destroy(_ {{ type_name }}) {}
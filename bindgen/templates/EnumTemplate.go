@@ -3,6 +3,8 @@
  * file, You can obtain one at http://mozilla.org/MPL/2.0/. */#}
 
 {% let e = ci.get_enum_definition(name).expect("missing enum") -%}
+{%- let fn_errors = config.recovered_errors() -%}
+{%- let fallback = config.non_exhaustive_fallback(name) -%}
 {%- if e.is_flat() -%}
 
 {%- call go::docstring(e, 0) %}
@@ -25,6 +27,21 @@ const (
 	{%- endfor %}
 )
 {%- endif %}
+{%- if fallback %}
+
+// IsUnknown reports whether e is a variant added in a newer version of the Rust library. Such
+// values keep their raw discriminant, but can not be passed back to Rust.
+func (e {{ type_name }}) IsUnknown() bool {
+	switch e {
+	{%- for variant in e.variants() %}
+	case {{ type_name }}{{ variant.name()|enum_variant_name }}:
+		return false
+	{%- endfor %}
+	default:
+		return true
+	}
+}
+{%- endif %}
 
 {%- else %}
 
@@ -32,7 +49,7 @@ const (
 type {{ type_name }} interface {
 	Destroy()
 	{%- for meth in e.methods() %}
-	{{ meth.name()|fn_name }}({%- call go::arg_list_decl(meth) -%}) {% call go::return_type_decl(meth) %}
+	{{ meth.name()|fn_name }}({%- call go::arg_list_decl(meth) -%}) {% call go::method_return_type_decl(meth, fn_errors) %}
 	{%- endfor %}
 }
 
@@ -50,9 +67,60 @@ func (e {{ type_name }}{{ variant.name()|class_name }}) Destroy() {
 	{%- endfor %}
 }
 {%- endfor %}
+{%- if fallback %}
+
+// {{ type_name }}UnknownVariant is a variant added in a newer version of the Rust library than
+// these bindings were generated for. Its fields can not be read, and it can not be passed back
+// to Rust.
+type {{ type_name }}UnknownVariant struct {
+	// Discriminant of the variant in the Rust library, starting at 1
+	Discriminant int32
+}
+
+func (e {{ type_name }}UnknownVariant) Destroy() {}
+{%- endif %}
+
+{%- endif %}
+
+{%- if config.log_valuer() %}
+{%- if self.include_once_check("LogValuer.go") %}{% include "LogValuer.go" %}{% endif %}
+{%- if e.is_flat() %}
+
+func (e {{ type_name }}) LogValue() slog.Value {
+	switch e {
+	{%- for variant in e.variants() %}
+	case {{ type_name }}{{ variant.name()|enum_variant_name }}:
+		return slog.StringValue("{{ variant.name() }}")
+	{%- endfor %}
+	default:
+		return slog.Int64Value(int64(e))
+	}
+}
+{%- else %}
+{%- for variant in e.variants() %}
+
+func (e {{ type_name }}{{ variant.name()|class_name }}) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("variant", "{{ variant.name() }}"),
+		{%- for field in variant.fields() %}
+		{%- call go::log_attr(field, field.name().to_string()|or_pos_field(loop.index0), "e", field.name()|field_name|or_pos_field(loop.index0), config.sensitive_variant_field(name, variant.name(), field.name())) %}
+		{%- endfor %}
+	)
+}
+{%- endfor %}
+{%- if fallback %}
 
+func (e {{ type_name }}UnknownVariant) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("variant", "unknown"),
+		slog.Int64("discriminant", int64(e.Discriminant)),
+	)
+}
+{%- endif %}
+{%- endif %}
 {%- endif %}
 
+{%- let release_self = type_|release_lowered("_selfBuf", ci) %}
 {%- if e.is_flat() %}
 {%- let trait_methods = e.uniffi_trait_methods() %}
 {%- let receiver_type = type_name %}
@@ -63,28 +131,56 @@ func (e {{ type_name }}{{ variant.name()|class_name }}) Destroy() {
 {%- if e.is_flat() %}
 {%- for meth in e.methods() %}
 {%- call go::docstring(meth, 0) %}
-func (_self {{ type_name }}) {{ meth.name()|fn_name }}({%- call go::arg_list_decl(meth) -%}) {% call go::return_type_decl(meth) %} {
+func (_self {{ type_name }}) {{ meth.name()|fn_name }}({%- call go::arg_list_decl(meth) -%}) {% call go::fn_return_type_decl(meth, fn_errors) %} {
+	{%- call go::recover_rust_panic(meth, fn_errors) %}
 	_selfBuf := {{ ffi_converter_instance }}.Lower(_self)
 	{% if meth.is_async() %}
-	{% call go::async_ffi_call_binding(meth, "_selfBuf") %}
+	{% call go::async_ffi_call_binding(meth, "_selfBuf", fn_errors, release_self) %}
 	{% else %}
-	{% call go::ffi_call_binding(meth, "_selfBuf") %}
+	{% call go::ffi_call_binding(meth, "_selfBuf", fn_errors, release_self) %}
 	{% endif %}
 }
+{%- if meth.is_async() %}
+{% call go::async_ctx_docstring(meth.name()|fn_name) %}
+func (_self {{ type_name }}) {{ meth.name()|fn_name }}Ctx({%- call go::async_ctx_arg_list_decl(meth) -%}) {% call go::fn_return_type_decl(meth, true) %} {
+	{%- call go::recover_rust_panic(meth, true) %}
+	_selfBuf := {{ ffi_converter_instance }}.Lower(_self)
+	{% call go::async_ctx_ffi_call_binding(meth, "_selfBuf", release_self) %}
+}
+{% call go::async_future_docstring(meth.name()|fn_name) %}
+func (_self {{ type_name }}) {{ meth.name()|fn_name }}Future({%- call go::arg_list_decl(meth) -%}) {% call go::async_future_return_type(meth) %} {
+	_selfBuf := {{ ffi_converter_instance }}.Lower(_self)
+	{% call go::async_future_ffi_call_binding(meth, "_selfBuf", release_self) %}
+}
+{%- endif %}
 
 {%- endfor %}
 {%- else %}
-{%- for variant in e.variants() %}
+{%- for variant_type_name in self.variant_type_names(e) %}
 {%- for meth in e.methods() %}
 {%- call go::docstring(meth, 0) %}
-func (_self {{ type_name }}{{ variant.name()|class_name }}) {{ meth.name()|fn_name }}({%- call go::arg_list_decl(meth) -%}) {% call go::return_type_decl(meth) %} {
+func (_self {{ variant_type_name }}) {{ meth.name()|fn_name }}({%- call go::arg_list_decl(meth) -%}) {% call go::fn_return_type_decl(meth, fn_errors) %} {
+	{%- call go::recover_rust_panic(meth, fn_errors) %}
 	_selfBuf := {{ ffi_converter_instance }}.Lower(_self)
 	{% if meth.is_async() %}
-	{% call go::async_ffi_call_binding(meth, "_selfBuf") %}
+	{% call go::async_ffi_call_binding(meth, "_selfBuf", fn_errors, release_self) %}
 	{% else %}
-	{% call go::ffi_call_binding(meth, "_selfBuf") %}
+	{% call go::ffi_call_binding(meth, "_selfBuf", fn_errors, release_self) %}
 	{% endif %}
 }
+{%- if meth.is_async() %}
+{% call go::async_ctx_docstring(meth.name()|fn_name) %}
+func (_self {{ variant_type_name }}) {{ meth.name()|fn_name }}Ctx({%- call go::async_ctx_arg_list_decl(meth) -%}) {% call go::fn_return_type_decl(meth, true) %} {
+	{%- call go::recover_rust_panic(meth, true) %}
+	_selfBuf := {{ ffi_converter_instance }}.Lower(_self)
+	{% call go::async_ctx_ffi_call_binding(meth, "_selfBuf", release_self) %}
+}
+{% call go::async_future_docstring(meth.name()|fn_name) %}
+func (_self {{ variant_type_name }}) {{ meth.name()|fn_name }}Future({%- call go::arg_list_decl(meth) -%}) {% call go::async_future_return_type(meth) %} {
+	_selfBuf := {{ ffi_converter_instance }}.Lower(_self)
+	{% call go::async_future_ffi_call_binding(meth, "_selfBuf", release_self) %}
+}
+{%- endif %}
 
 {%- endfor %}
 {%- endfor %}
@@ -107,16 +203,30 @@ func (c {{ ffi_converter_name }}) LowerExternal(value {{ type_name }}) ExternalC
 }
 
 {%- if e.is_flat() %}
-func ({{ ffi_converter_name }}) Read(reader io.Reader) {{ type_name }} {
+func ({{ ffi_converter_name }}) Read(reader *bytes.Reader) {{ type_name }} {
 	id := readInt32(reader)
+	{%- if !fallback %}
+	if id < 1 || id > {{ e.variants().len() }} {
+		panic(&LiftError{TypeName: "{{ type_name }}", Reason: fmt.Sprintf("invalid enum value %d", id)})
+	}
+	{%- endif %}
 	return {{ type_name }}(id)
 }
 
-func ({{ ffi_converter_name }}) Write(writer io.Writer, value {{ type_name }}) {
+func ({{ ffi_converter_name }}) Write(writer *bytes.Buffer, value {{ type_name }}) {
+	{%- if fallback %}
+	if value.IsUnknown() {
+		panic(&LowerError{Reason: fmt.Sprintf("{{ type_name }} value %d is unknown to these bindings", value)})
+	}
+	{%- endif %}
 	writeInt32(writer, int32(value))
 }
+
+func ({{ ffi_converter_name }}) AllocationSize(_ {{ type_name }}) uint64 {
+	return 4
+}
 {%- else %}
-func ({{ ffi_converter_name }}) Read(reader io.Reader) {{ type_name }} {
+func ({{ ffi_converter_name }}) Read(reader *bytes.Reader) {{ type_name }} {
 	id := readInt32(reader)
 	switch (id) {
 		{%- for variant in e.variants() %}
@@ -128,11 +238,17 @@ func ({{ ffi_converter_name }}) Read(reader io.Reader) {{ type_name }} {
 			};
 		{%- endfor %}
 		default:
-			panic(fmt.Sprintf("invalid enum value %v in {{ ffi_converter_name }}.Read()", id));
+			{%- if fallback %}
+			// The fields of an unknown variant can not be read, the rest of the buffer is skipped
+			skipRemaining(reader, "{{ type_name }}")
+			return {{ type_name }}UnknownVariant{Discriminant: id}
+			{%- else %}
+			panic(&LiftError{TypeName: "{{ type_name }}", Reason: fmt.Sprintf("invalid enum value %d", id)})
+			{%- endif %}
 	}
 }
 
-func ({{ ffi_converter_name }}) Write(writer io.Writer, value {{ type_name }}) {
+func ({{ ffi_converter_name }}) Write(writer *bytes.Buffer, value {{ type_name }}) {
 	switch variant_value := value.(type) {
 		{%- for variant in e.variants() %}
 		case {{ type_name }}{{ variant.name()|class_name }}:
@@ -141,11 +257,31 @@ func ({{ ffi_converter_name }}) Write(writer io.Writer, value {{ type_name }}) {
 			{{ field|write_fn(ci) }}(writer, variant_value.{{ field.name()|field_name|or_pos_field(loop.index0) }})
 			{%- endfor %}
 		{%- endfor %}
+		{%- if fallback %}
+		case {{ type_name }}UnknownVariant:
+			panic(&LowerError{Reason: fmt.Sprintf("{{ type_name }} variant %d is unknown to these bindings", variant_value.Discriminant)})
+		{%- endif %}
 		default:
 			_ = variant_value
 			panic(fmt.Sprintf("invalid enum value `%v` in {{ ffi_converter_name }}.Write", value))
 	}
 }
+
+func ({{ ffi_converter_name }}) AllocationSize(value {{ type_name }}) uint64 {
+	size := uint64(4)
+	switch variant_value := value.(type) {
+		{%- for variant in e.variants() %}
+		case {{ type_name }}{{ variant.name()|class_name }}:
+			{%- for field in variant.fields() %}
+			size += {{ field|allocation_size_fn(ci) }}(variant_value.{{ field.name()|field_name|or_pos_field(loop.index0) }})
+			{%- endfor %}
+		{%- endfor %}
+		default:
+			// Write panics for any other value
+			_ = variant_value
+	}
+	return size
+}
 {%- endif %}
 
 type {{ ffi_destroyer_name }} struct {}
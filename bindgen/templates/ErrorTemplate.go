@@ -2,6 +2,9 @@
  * License, v. 2.0. If a copy of the MPL was not distributed with this
  * file, You can obtain one at http://mozilla.org/MPL/2.0/. */#}
 
+{{- self.add_import("errors") }}
+{%- let fallback = config.non_exhaustive_fallback(name) %}
+
 {%- call go::docstring(e, 0) %}
 type {{ canonical_type_name }} struct {
 	err error
@@ -25,6 +28,17 @@ func (err {{ canonical_type_name }}) Unwrap() error {
 	return err.err
 }
 
+{%- if config.log_valuer() %}
+{%- if self.include_once_check("LogValuer.go") %}{% include "LogValuer.go" %}{% endif %}
+
+func (err {{ canonical_type_name }}) LogValue() slog.Value {
+	if valuer, ok := err.err.(slog.LogValuer); ok {
+		return valuer.LogValue()
+	}
+	return slog.AnyValue(err.err)
+}
+{%- endif %}
+
 // Err* are used for checking error type with `errors.Is`
 {%- for variant in e.variants() %}
 {%- let variant_class_name = (canonical_type_name.clone() + variant.name())|class_name %}
@@ -89,8 +103,139 @@ func (err {{ variant_class_name }}) Error() string {
 func (self {{ variant_class_name }}) Is(target error) bool {
 	return target == Err{{ variant_class_name }}
 }
+{%- if config.log_valuer() %}
+
+func (err {{ variant_class_name }}) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("variant", "{{ variant.name() }}"),
+		{%- if e.is_flat() %}
+		slog.String("message", err.message),
+		{%- else %}
+		{%- for field in variant.fields() %}
+		{%- call go::log_attr(field, field.name().to_string()|or_pos_field(loop.index0), "err", field.name()|error_field_name|or_pos_field(loop.index0), config.sensitive_variant_field(name, variant.name(), field.name())) %}
+		{%- endfor %}
+		{%- endif %}
+	)
+}
+{%- endif %}
 
 {%- endfor %}
+{%- if fallback %}
+
+// Err{{ canonical_type_name }}UnknownVariant is used for checking for unknown variants with `errors.Is`
+var Err{{ canonical_type_name }}UnknownVariant = fmt.Errorf("{{ canonical_type_name }}UnknownVariant")
+
+// {{ canonical_type_name }}UnknownVariant is a variant added in a newer version of the Rust library
+// than these bindings were generated for. Its fields can not be read, and it can not be passed
+// back to Rust.
+type {{ canonical_type_name }}UnknownVariant struct {
+	// Discriminant of the variant in the Rust library, starting at 1
+	Discriminant int32
+	{%- if e.is_flat() %}
+	message string
+	{%- endif %}
+}
+
+func (err {{ canonical_type_name }}UnknownVariant) Error() string {
+	{%- if e.is_flat() %}
+	return fmt.Sprintf("UnknownVariant(%d): %s", err.Discriminant, err.message)
+	{%- else %}
+	return fmt.Sprintf("UnknownVariant(%d)", err.Discriminant)
+	{%- endif %}
+}
+
+func (self {{ canonical_type_name }}UnknownVariant) Is(target error) bool {
+	return target == Err{{ canonical_type_name }}UnknownVariant
+}
+{%- if config.log_valuer() %}
+
+func (err {{ canonical_type_name }}UnknownVariant) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("variant", "unknown"),
+		slog.Int64("discriminant", int64(err.Discriminant)),
+		{%- if e.is_flat() %}
+		slog.String("message", err.message),
+		{%- endif %}
+	)
+}
+{%- endif %}
+
+// IsUnknown reports whether err holds a variant unknown to these bindings
+func (err {{ canonical_type_name }}) IsUnknown() bool {
+	_, ok := err.err.(*{{ canonical_type_name }}UnknownVariant)
+	return ok
+}
+{%- endif %}
+
+// {{ canonical_type_name }}Variant identifies the variant held by a {{ canonical_type_name }}
+type {{ canonical_type_name }}Variant int
+
+const (
+	{%- for variant in e.variants() %}
+	{{ canonical_type_name }}Variant{{ variant.name()|class_name }} {{ canonical_type_name }}Variant = {{ loop.index }}
+	{%- endfor %}
+)
+
+// Variant returns the variant held by err, or 0 if it holds none
+{%- if fallback %}. Unknown variants report
+// their raw discriminant.
+{%- endif %}
+func (err {{ canonical_type_name }}) Variant() {{ canonical_type_name }}Variant {
+	switch {% if fallback %}variant := {% endif %}err.err.(type) {
+	{%- for variant in e.variants() %}
+	case *{{ canonical_type_name }}{{ variant.name()|class_name }}:
+		return {{ canonical_type_name }}Variant{{ variant.name()|class_name }}
+	{%- endfor %}
+	{%- if fallback %}
+	case *{{ canonical_type_name }}UnknownVariant:
+		return {{ canonical_type_name }}Variant(variant.Discriminant)
+	{%- endif %}
+	default:
+		return 0
+	}
+}
+{%- for variant in e.variants() %}
+{%- let variant_class_name = (canonical_type_name.clone() + variant.name())|class_name %}
+{%- let variant_name = variant.name()|class_name %}
+
+// As{{ variant_name }}{% if variant_name == "Error" %}_{% endif %} returns the {{ variant_name }} variant, if err holds it
+func (err {{ canonical_type_name }}) As{{ variant_name }}{% if variant_name == "Error" %}_{% endif %}() (*{{ variant_class_name }}, bool) {
+	variant, ok := err.err.(*{{ variant_class_name }})
+	return variant, ok
+}
+{%- endfor %}
+
+// Is matches errors holding the same variant as err, also when target wraps them. This makes
+// errors.Is(err, New{{ canonical_type_name }}...()) work just like the Err{{ canonical_type_name }}... values.
+func (err {{ canonical_type_name }}) Is(target error) bool {
+	var other *{{ canonical_type_name }}
+	if !errors.As(target, &other) || other == nil {
+		return false
+	}
+	return err.Variant() != 0 && err.Variant() == other.Variant()
+}
+
+// As allows errors.As to also fill in variant values, not just pointers to them
+func (err {{ canonical_type_name }}) As(target any) bool {
+	switch target := target.(type) {
+	{%- for variant in e.variants() %}
+	{%- let variant_class_name = (canonical_type_name.clone() + variant.name())|class_name %}
+	case *{{ variant_class_name }}:
+		if variant, ok := err.err.(*{{ variant_class_name }}); ok && variant != nil {
+			*target = *variant
+			return true
+		}
+	{%- endfor %}
+	{%- if fallback %}
+	case *{{ canonical_type_name }}UnknownVariant:
+		if variant, ok := err.err.(*{{ canonical_type_name }}UnknownVariant); ok && variant != nil {
+			*target = *variant
+			return true
+		}
+	{%- endif %}
+	}
+	return false
+}
 
 type {{ ffi_converter_name }} struct{}
 
@@ -108,7 +253,7 @@ func (c {{ ffi_converter_name }}) LowerExternal(value {{ type_name }}) ExternalC
 	return RustBufferFromC(LowerIntoRustBuffer[{{ type_name }}](c, value))
 }
 
-func (c {{ ffi_converter_name }}) Read(reader io.Reader) {{ type_name }} {
+func (c {{ ffi_converter_name }}) Read(reader *bytes.Reader) {{ type_name }} {
 	errorID := readUint32(reader)
 
 	{%- if e.is_flat() %}
@@ -120,7 +265,11 @@ func (c {{ ffi_converter_name }}) Read(reader io.Reader) {{ type_name }} {
 		return &{{ canonical_type_name }}{ &{{- canonical_type_name }}{{ variant.name()|class_name }}{message}}
 	{%- endfor %}
 	default:
-		panic(fmt.Sprintf("Unknown error code %d in {{ e|ffi_converter_name(ci) }}.Read()", errorID))
+		{%- if fallback %}
+		return &{{ canonical_type_name }}{ &{{- canonical_type_name }}UnknownVariant{int32(errorID), message}}
+		{%- else %}
+		panic(&LiftError{TypeName: "{{ canonical_type_name }}", Reason: fmt.Sprintf("unknown error code %d", errorID)})
+		{%- endif %}
 	}
 
 	{% else %}
@@ -135,13 +284,19 @@ func (c {{ ffi_converter_name }}) Read(reader io.Reader) {{ type_name }} {
 		}}
 	{%- endfor %}
 	default:
-		panic(fmt.Sprintf("Unknown error code %d in {{ e|ffi_converter_name(ci) }}.Read()", errorID))
+		{%- if fallback %}
+		// The fields of an unknown variant can not be read, the rest of the buffer is skipped
+		skipRemaining(reader, "{{ canonical_type_name }}")
+		return &{{ canonical_type_name }}{ &{{- canonical_type_name }}UnknownVariant{Discriminant: int32(errorID)}}
+		{%- else %}
+		panic(&LiftError{TypeName: "{{ canonical_type_name }}", Reason: fmt.Sprintf("unknown error code %d", errorID)})
+		{%- endif %}
 	}
 
 	{%- endif %}
 }
 
-func (c {{ ffi_converter_name }}) Write(writer io.Writer, value {{ type_name }}) {
+func (c {{ ffi_converter_name }}) Write(writer *bytes.Buffer, value {{ type_name }}) {
 	switch variantValue := value.err.(type) {
 		{%- for variant in e.variants() %}
 		case *{{ canonical_type_name }}{{ variant.name()|class_name }}:
@@ -150,12 +305,32 @@ func (c {{ ffi_converter_name }}) Write(writer io.Writer, value {{ type_name }})
 			{{ field|write_fn(ci) }}(writer, variantValue.{{ field.name()|error_field_name|or_pos_field(loop.index0) }})
 			{%- endfor %}
 		{%- endfor %}
+		{%- if fallback %}
+		case *{{ canonical_type_name }}UnknownVariant:
+			panic(&LowerError{Reason: fmt.Sprintf("{{ canonical_type_name }} variant %d is unknown to these bindings", variantValue.Discriminant)})
+		{%- endif %}
 		default:
 			_ = variantValue
 			panic(fmt.Sprintf("invalid error value `%v` in {{ e|ffi_converter_name(ci) }}.Write", value))
 	}
 }
 
+func (c {{ ffi_converter_name }}) AllocationSize(value {{ type_name }}) uint64 {
+	size := uint64(4)
+	switch variantValue := value.err.(type) {
+		{%- for variant in e.variants() %}
+		case *{{ canonical_type_name }}{{ variant.name()|class_name }}:
+			{%- for field in variant.fields() %}
+			size += {{ field|allocation_size_fn(ci) }}(variantValue.{{ field.name()|error_field_name|or_pos_field(loop.index0) }})
+			{%- endfor %}
+		{%- endfor %}
+		default:
+			// Write panics for any other value
+			_ = variantValue
+	}
+	return size
+}
+
 type {{ ffi_destroyer_name }} struct {}
 
 func (_ {{ ffi_destroyer_name }}) Destroy(value {{ type_name }}) {
@@ -164,6 +339,9 @@ func (_ {{ ffi_destroyer_name }}) Destroy(value {{ type_name }}) {
 		case {{ canonical_type_name }}{{ variant.name()|class_name }}:
 			variantValue.destroy()
 		{%- endfor %}
+		{%- if fallback %}
+		case {{ canonical_type_name }}UnknownVariant:
+		{%- endif %}
 		default:
 			_ = variantValue
 			panic(fmt.Sprintf("invalid error value `%v` in {{ ffi_destroyer_name }}.Destroy", value))
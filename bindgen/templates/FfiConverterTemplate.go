@@ -11,35 +11,142 @@ type BufLowerer[GoType any] interface {
 }
 
 type BufReader[GoType any] interface {
-	Read(reader io.Reader) GoType
+	Read(reader *bytes.Reader) GoType
 }
 
 type BufWriter[GoType any] interface {
-	Write(writer io.Writer, value GoType)
+	Write(writer *bytes.Buffer, value GoType)
+	// Number of bytes Write takes for the value
+	AllocationSize(value GoType) uint64
 }
 
+// Implemented by the converters of values that can hold object handles. Handles written before
+// a failing Write are tracked per buffer, so they can be released along with it.
+type uniffiHandleWriter interface {
+	writesHandles()
+}
+
+type uniffiWrittenHandle struct {
+	handle  uint64
+	release func(uint64)
+}
+
+// Maps the *bytes.Buffer of a running LowerIntoRustBuffer to its *[]uniffiWrittenHandle
+var uniffiWrittenHandles sync.Map
+
+func uniffiTrackWrittenHandle(writer *bytes.Buffer, handle uint64, release func(uint64)) {
+	if handles, ok := uniffiWrittenHandles.Load(writer); ok {
+		list := handles.(*[]uniffiWrittenHandle)
+		*list = append(*list, uniffiWrittenHandle{handle, release})
+	}
+}
+
+// Implemented by the converters of values holding custom types. Sizing them would run each
+// custom conversion twice, so they are written into a pooled scratch buffer instead.
+type uniffiCustomTypeWriter interface {
+	writesCustomTypes()
+}
+
+var uniffiScratchBuffers = sync.Pool{
+	New: func() any {
+		return new(bytes.Buffer)
+	},
+}
+
+// Scratch buffers that grew past this size are left to the garbage collector
+const uniffiMaxPooledScratchBuffer = 64 * 1024
+
 func LowerIntoRustBuffer[GoType any](bufWriter BufWriter[GoType], value GoType) C.RustBuffer {
-	// This might be not the most efficient way but it does not require knowing allocation size
-	// beforehand
-	var buffer bytes.Buffer
-	bufWriter.Write(&buffer, value)
-
-	bytes, err := io.ReadAll(&buffer)
-	if err != nil {
-		panic(fmt.Errorf("reading written data: %w", err))
+	if _, ok := bufWriter.(uniffiCustomTypeWriter); ok {
+		return uniffiLowerThroughScratch(bufWriter, value)
+	}
+
+	size := bufWriter.AllocationSize(value)
+	if size == 0 {
+		return C.RustBuffer{}
+	}
+	rbuf := rustCall(func(status *C.RustCallStatus) C.RustBuffer {
+		return C.{{ ci.ffi_rustbuffer_alloc().name() }}(C.uint64_t(size), status)
+	})
+	// Values are written straight into the memory allocated by Rust
+	memory := unsafe.Slice((*byte)(unsafe.Pointer(rbuf.data)), rbuf.capacity)
+	writer := bytes.NewBuffer(memory[:0])
+	uniffiWriteReleasingHandles(bufWriter, writer, value, func() {
+		GoRustBuffer{inner: rbuf}.Free()
+	})
+
+	if data := writer.Bytes(); len(data) > len(memory) {
+		// The size came up short, which generated converters without custom types never do,
+		// so the buffer moved to Go memory and has to be copied over
+		GoRustBuffer{inner: rbuf}.Free()
+		return bytesToRustBuffer(data)
+	}
+	rbuf.len = C.uint64_t(writer.Len())
+	return rbuf
+}
+
+func uniffiLowerThroughScratch[GoType any](bufWriter BufWriter[GoType], value GoType) C.RustBuffer {
+	scratch := uniffiScratchBuffers.Get().(*bytes.Buffer)
+	defer func() {
+		if scratch.Cap() <= uniffiMaxPooledScratchBuffer {
+			scratch.Reset()
+			uniffiScratchBuffers.Put(scratch)
+		}
+	}()
+	uniffiWriteReleasingHandles(bufWriter, scratch, value, func() {})
+	return bytesToRustBuffer(scratch.Bytes())
+}
+
+// Writes value, or releases the handles written so far and calls free when Write panics on a
+// value that can not be lowered
+func uniffiWriteReleasingHandles[GoType any](bufWriter BufWriter[GoType], writer *bytes.Buffer, value GoType, free func()) {
+	written := false
+	handles := &[]uniffiWrittenHandle{}
+	if _, ok := bufWriter.(uniffiHandleWriter); ok {
+		uniffiWrittenHandles.Store(writer, handles)
+		defer uniffiWrittenHandles.Delete(writer)
 	}
-	return bytesToRustBuffer(bytes)
+	defer func() {
+		if !written {
+			for _, written := range *handles {
+				written.release(written.handle)
+			}
+			free()
+		}
+	}()
+	bufWriter.Write(writer, value)
+	written = true
 }
 
 func LiftFromRustBuffer[GoType any](bufReader BufReader[GoType], rbuf RustBufferI) GoType {
 	defer rbuf.Free()
 	reader := rbuf.AsReader()
+	defer uniffiLocateLiftError[GoType](reader)
 	item := bufReader.Read(reader)
 	if reader.Len() > 0 {
-		// TODO: Remove this
-		leftover, _ := io.ReadAll(reader)
-		panic(fmt.Errorf("Junk remaining in buffer after lifting: %s", string(leftover)))
+		panic(&LiftError{Reason: fmt.Sprintf("%d bytes remaining in buffer after lifting", reader.Len())})
 	}
 	return item
 }
 
+// Fills in the lifted type and the reader position of a *LiftError raised while reading,
+// other panics are re-raised unchanged. Reads that failed after an unknown variant skipped the
+// rest of the buffer are reported as such.
+func uniffiLocateLiftError[GoType any](reader *bytes.Reader) {
+	skipped, hasSkipped := uniffiSkippedReaders.LoadAndDelete(reader)
+	if r := recover(); r != nil {
+		if liftErr, ok := r.(*LiftError); ok {
+			if liftErr.TypeName == "" {
+				// Formatting a pointer type names interface types too, the leading * is dropped
+				liftErr.TypeName = fmt.Sprintf("%T", (*GoType)(nil))[1:]
+			}
+			if hasSkipped {
+				liftErr.Reason = fmt.Sprintf(
+					"values after an unknown variant of %s can not be read, as its fields were skipped to the end of the buffer: %s",
+					skipped, liftErr.Reason)
+			}
+			liftErr.Offset = reader.Size() - int64(reader.Len())
+		}
+		panic(r)
+	}
+}
@@ -10,15 +10,19 @@ func ({{ ffi_converter_name }}) Lower(value float32) C.float {
 	return C.float(value)
 }
 
-func ({{ ffi_converter_name }}) Write(writer io.Writer, value float32) {
+func ({{ ffi_converter_name }}) Write(writer *bytes.Buffer, value float32) {
 	writeFloat32(writer, value)
 }
 
+func ({{ ffi_converter_name }}) AllocationSize(_ float32) uint64 {
+	return 4
+}
+
 func ({{ ffi_converter_name }}) Lift(value C.float) float32 {
 	return float32(value)
 }
 
-func ({{ ffi_converter_name }}) Read(reader io.Reader) float32 {
+func ({{ ffi_converter_name }}) Read(reader *bytes.Reader) float32 {
 	return readFloat32(reader)
 }
 
@@ -10,15 +10,19 @@ func ({{ ffi_converter_name }}) Lower(value float64) C.double {
 	return C.double(value)
 }
 
-func ({{ ffi_converter_name }}) Write(writer io.Writer, value float64) {
+func ({{ ffi_converter_name }}) Write(writer *bytes.Buffer, value float64) {
 	writeFloat64(writer, value)
 }
 
+func ({{ ffi_converter_name }}) AllocationSize(_ float64) uint64 {
+	return 8
+}
+
 func ({{ ffi_converter_name }}) Lift(value C.double) float64 {
 	return float64(value)
 }
 
-func ({{ ffi_converter_name }}) Read(reader io.Reader) float64 {
+func ({{ ffi_converter_name }}) Read(reader *bytes.Reader) float64 {
 	return readFloat64(reader)
 }
 
@@ -18,10 +18,14 @@ func (c FfiConverterForeignExecutor) Lower(value UniFfiForeignExecutor) C.int {
 	return 0;
 }
 
-func (c FfiConverterForeignExecutor) Write(writer io.Writer, value UniFfiForeignExecutor) {
+func (c FfiConverterForeignExecutor) Write(writer *bytes.Buffer, value UniFfiForeignExecutor) {
 	writeUint64(writer, uint64(c.Lower(value)))
 }
 
+func (c FfiConverterForeignExecutor) AllocationSize(_ UniFfiForeignExecutor) uint64 {
+	return 8
+}
+
 func (c FfiConverterForeignExecutor) Lift(value C.int) UniFfiForeignExecutor {
 	if value != 0 {
 		panic(fmt.Errorf("Invalid executor pointer: %d", value))
@@ -29,7 +33,7 @@ func (c FfiConverterForeignExecutor) Lift(value C.int) UniFfiForeignExecutor {
 	return UniFfiForeignExecutor{}
 }
 
-func (c FfiConverterForeignExecutor) Read(reader io.Reader) UniFfiForeignExecutor {
+func (c FfiConverterForeignExecutor) Read(reader *bytes.Reader) UniFfiForeignExecutor {
 	return c.Lift(C.int(readUint64(reader)))
 }
 
@@ -0,0 +1,159 @@
+{#/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */#}
+
+// Fuzz targets for the converters of the {{ ci.namespace() }} package, generated with
+// `fuzz_tests = true`. Run one with `go test -fuzz=FuzzTimestamp`.
+package {{ ci.namespace() }}
+
+import (
+	"bytes"
+	"math"
+	"reflect"
+	"testing"
+)
+
+func uniffiFuzzSeeds(f *testing.F) {
+	f.Add([]byte{})
+	f.Add(make([]byte, 64))
+	f.Add(append([]byte{0, 0, 0, 1}, make([]byte, 60)...))
+	f.Add(bytes.Repeat([]byte{0xff}, 64))
+}
+
+// Reads a value from data. Reading may only fail with a *LiftError.
+func uniffiFuzzRead[T any](t *testing.T, read func(*bytes.Reader) T, data []byte) (value T, ok bool) {
+	reader := bytes.NewReader(data)
+	// Readers are only forgotten by LiftFromRustBuffer after skipping unknown variants
+	defer uniffiSkippedReaders.Delete(reader)
+	defer func() {
+		if r := recover(); r != nil {
+			if _, isLiftErr := r.(*LiftError); !isLiftErr {
+				t.Fatalf("reading %v panicked: %v", reflect.TypeOf((*T)(nil)).Elem(), r)
+			}
+			ok = false
+		}
+	}()
+	return read(reader), true
+}
+
+// Writes a value, which must take the reported allocation size. Writing may only fail with a
+// *LowerError, e.g. for negative durations or variants unknown to the bindings.
+func uniffiFuzzWrite[T any](t *testing.T, write func(*bytes.Buffer, T), allocationSize func(T) uint64, value T) (data []byte, ok bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			if _, isLowerErr := r.(*LowerError); !isLowerErr {
+				t.Fatalf("writing %v panicked: %v", reflect.TypeOf((*T)(nil)).Elem(), r)
+			}
+			ok = false
+		}
+	}()
+	var buffer bytes.Buffer
+	write(&buffer, value)
+	if size := allocationSize(value); uint64(buffer.Len()) != size {
+		t.Fatalf("writing %T took %d bytes, AllocationSize reported %d", value, buffer.Len(), size)
+	}
+	return buffer.Bytes(), true
+}
+
+// Checks that a value read from data is read back unchanged after writing it
+func uniffiFuzzRoundTrip[T any](t *testing.T, read func(*bytes.Reader) T, write func(*bytes.Buffer, T), allocationSize func(T) uint64, data []byte) {
+	value, ok := uniffiFuzzRead(t, read, data)
+	if !ok {
+		return
+	}
+	written, ok := uniffiFuzzWrite(t, write, allocationSize, value)
+	if !ok {
+		return
+	}
+	{%- if config.replace_invalid_utf8() %}
+	// Invalid UTF-8 read from data is replaced when written, compare from the replaced value on
+	value = read(bytes.NewReader(written))
+	written, _ = uniffiFuzzWrite(t, write, allocationSize, value)
+	{%- endif %}
+
+	reader := bytes.NewReader(written)
+	roundTripped := read(reader)
+	if reader.Len() > 0 {
+		t.Fatalf("%d bytes remaining after reading back %T", reader.Len(), value)
+	}
+	if !uniffiFuzzEqual(reflect.ValueOf(value), reflect.ValueOf(roundTripped)) {
+		t.Fatalf("%T changed in a round trip: %#v became %#v", value, value, roundTripped)
+	}
+}
+
+// Like reflect.DeepEqual, but NaN equals NaN and unexported fields are compared too
+func uniffiFuzzEqual(a, b reflect.Value) bool {
+	if a.Kind() != b.Kind() {
+		return false
+	}
+	switch a.Kind() {
+	case reflect.Invalid:
+		return true
+	case reflect.Bool:
+		return a.Bool() == b.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return a.Int() == b.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return a.Uint() == b.Uint()
+	case reflect.Float32, reflect.Float64:
+		return a.Float() == b.Float() || (math.IsNaN(a.Float()) && math.IsNaN(b.Float()))
+	case reflect.String:
+		return a.String() == b.String()
+	case reflect.Ptr, reflect.Interface:
+		if a.IsNil() || b.IsNil() {
+			return a.IsNil() == b.IsNil()
+		}
+		if a.Kind() == reflect.Ptr && a.Pointer() == b.Pointer() {
+			return true
+		}
+		return a.Elem().Type() == b.Elem().Type() && uniffiFuzzEqual(a.Elem(), b.Elem())
+	case reflect.Struct:
+		if a.Type() != b.Type() {
+			return false
+		}
+		for i := 0; i < a.NumField(); i++ {
+			if !uniffiFuzzEqual(a.Field(i), b.Field(i)) {
+				return false
+			}
+		}
+		return true
+	case reflect.Slice, reflect.Array:
+		if a.Len() != b.Len() {
+			return false
+		}
+		for i := 0; i < a.Len(); i++ {
+			if !uniffiFuzzEqual(a.Index(i), b.Index(i)) {
+				return false
+			}
+		}
+		return true
+	case reflect.Map:
+		if a.Len() != b.Len() {
+			return false
+		}
+		iter := a.MapRange()
+		for iter.Next() {
+			value := b.MapIndex(iter.Key())
+			if !value.IsValid() || !uniffiFuzzEqual(iter.Value(), value) {
+				return false
+			}
+		}
+		return true
+	default:
+		return false
+	}
+}
+{%- for target in self.targets() %}
+
+func Fuzz{{ target.name }}(f *testing.F) {
+	uniffiFuzzSeeds(f)
+	f.Fuzz(func(t *testing.T, data []byte) {
+		{%- if target.round_trip %}
+		uniffiFuzzRoundTrip(t, {{ target.converter }}.Read, {{ target.converter }}.Write, {{ target.converter }}.AllocationSize, data)
+		{%- else %}
+		// Flat errors are written without their message, they are only read
+		uniffiFuzzRead(t, {{ target.converter }}.Read, data)
+		{%- endif %}
+	})
+}
+{%- endfor %}
@@ -21,12 +21,12 @@ func checkCallStatus[E any](converter BufReader[E], status C.RustCallStatus) E {
 		// with the message.  but if that code panics, then it just sends back
 		// an empty buffer.
 		if status.errorBuf.len > 0 {
-			panic(fmt.Errorf("%s", {{ Type::String.borrow()|lift_fn(ci) }}(GoRustBuffer { inner: status.errorBuf })))
+			panic(&RustPanicError{Message: {{ Type::String.borrow()|lift_fn(ci) }}(GoRustBuffer { inner: status.errorBuf })})
 		} else {
-			panic(fmt.Errorf("Rust panicked while handling Rust panic"))
+			panic(&RustPanicError{Message: "Rust panicked while handling Rust panic"})
 		}
 	default:
-		panic(fmt.Errorf("unknown status code: %d", status.code))
+		panic(&RustPanicError{Message: fmt.Sprintf("unknown status code: %d", status.code)})
 	}
 }
 
@@ -41,17 +41,226 @@ func checkCallStatusUnknown(status C.RustCallStatus) error {
 		// with the message.  but if that code panics, then it just sends back
 		// an empty buffer.
 		if status.errorBuf.len > 0 {
-			panic(fmt.Errorf("%s", {{ Type::String.borrow()|lift_fn(ci) }}(GoRustBuffer {
+			panic(&RustPanicError{Message: {{ Type::String.borrow()|lift_fn(ci) }}(GoRustBuffer {
 				inner: status.errorBuf,
-			})))
+			})})
 		} else {
-			panic(fmt.Errorf("Rust panicked while handling Rust panic"))
+			panic(&RustPanicError{Message: "Rust panicked while handling Rust panic"})
 		}
 	default:
-		return fmt.Errorf("unknown status code: %d", status.code)
+		return &RustPanicError{Message: fmt.Sprintf("unknown status code: %d", status.code)}
 	}
 }
 
+// RustPanicError is a panic in Rust code, or a call status Rust is not expected to return.
+// Such failures are raised as Go panics carrying a *RustPanicError, unless the bindings were
+// generated with `panic_mode = "error"`, in which case they are returned as errors.
+type RustPanicError struct {
+	// The panic message
+	Message string
+	// Name of the FFI function that panicked, only set for returned errors
+	Function string
+}
+
+func (e *RustPanicError) Error() string {
+	if e.Function == "" {
+		return e.Message
+	}
+	return fmt.Sprintf("%s panicked: %s", e.Function, e.Message)
+}
+{%- if config.recovered_errors() %}
+
+// Deferred by generated functions to return Rust panics and lifting failures as errors, as
+// configured. Other panics are re-raised.
+func uniffiRecoverRustPanic(function string, err *error) {
+	if r := recover(); r != nil {
+		switch r := r.(type) {
+		{%- if config.panic_errors() %}
+		case *RustPanicError:
+			*err = &RustPanicError{Message: r.Message, Function: function}
+		{%- endif %}
+		{%- if config.lift_errors() %}
+		case *LiftError:
+			*err = r
+		{%- endif %}
+		default:
+			panic(r)
+		}
+	}
+}
+{%- endif %}
+
+// LowerError is returned when an argument can not be converted into its Rust representation,
+// for example a negative time.Duration. The Rust function is not called in that case.
+type LowerError struct {
+	// Name of the argument that failed to lower, empty while the error is raised by a converter
+	Argument string
+	// Why the value can not be lowered
+	Reason string
+
+	err error
+}
+
+func (e *LowerError) Error() string {
+	if e.Argument == "" {
+		return fmt.Sprintf("cannot lower value: %s", e.Reason)
+	}
+	return fmt.Sprintf("cannot lower argument %s: %s", e.Argument, e.Reason)
+}
+
+// UniffiLowerErrorReason returns Reason. It lets bindings of other packages recognize this
+// error when they lower external types through this package.
+func (e *LowerError) UniffiLowerErrorReason() string {
+	return e.Reason
+}
+
+// Unwrap returns the underlying error, such as one matching ErrObjectDestroyed for a destroyed object
+func (e *LowerError) Unwrap() error {
+	return e.err
+}
+
+// Converters raise *LowerError panics, which are turned into errors here. Other panics are re-raised.
+func uniffiLowerArg[T any, F any](argument string, value T, lower func(T) F) (_ F, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			reason, ok := uniffiLowerErrorReason(r)
+			if !ok {
+				panic(r)
+			}
+			lowerErr := &LowerError{Argument: argument, Reason: reason}
+			if cause, ok := r.(error); ok {
+				lowerErr.err = errors.Unwrap(cause)
+			}
+			err = lowerErr
+		}
+	}()
+	return lower(value), nil
+}
+
+// External types are lowered by the package defining them, which raises its own *LowerError.
+// Those are recognized by the method every generated *LowerError implements.
+func uniffiLowerErrorReason(r any) (string, bool) {
+	if lowerErr, ok := r.(interface{ UniffiLowerErrorReason() string }); ok {
+		return lowerErr.UniffiLowerErrorReason(), true
+	}
+	return "", false
+}
+
+// Runs a custom type conversion, raising its panics as *LowerError
+func uniffiLowerCustom[T any](typeName string, lower func() T) T {
+	defer func() {
+		if r := recover(); r != nil {
+			if _, ok := uniffiLowerErrorReason(r); ok {
+				panic(r)
+			}
+			panic(&LowerError{Reason: fmt.Sprintf("%s: %v", typeName, r)})
+		}
+	}()
+	return lower()
+}
+
+// Frees an already lowered argument when a later argument fails to lower. Arguments holding
+// object handles are released by lifting and destroying them instead.
+func uniffiFreeLowered(value any) {
+	switch value := value.(type) {
+	case C.RustBuffer:
+		GoRustBuffer{inner: value}.Free()
+	case RustBufferI:
+		value.Free()
+	}
+}
+
+// LiftError is raised when a value received from Rust can not be read, for example because of
+// a truncated buffer or an unknown enum discriminant. This points to a mismatch between the
+// bindings and the Rust library. It is raised as a Go panic carrying a *LiftError, unless the
+// bindings were generated with `lift_mode = "error"`, in which case it is returned as an error.
+type LiftError struct {
+	// Go type that was being lifted
+	TypeName string
+	// Byte offset in the buffer at which reading failed
+	Offset int64
+	// Why the value can not be lifted
+	Reason string
+}
+
+func (e *LiftError) Error() string {
+	return fmt.Sprintf("cannot lift %s at offset %d: %s", e.TypeName, e.Offset, e.Reason)
+}
+
+{%- if config.has_borrowed_results() %}
+
+// BorrowedString is a string returned by Rust without copying it out of the Rust buffer holding
+// it. The buffer stays allocated until Release is called, after which the string must no longer
+// be used. Returned by the functions listed in `borrowed_results`, requires Go 1.20.
+type BorrowedString struct {
+	rbuf     RustBufferI
+	released atomic.Bool
+}
+
+func uniffiBorrowString(rbuf RustBufferI) *BorrowedString {
+	return &BorrowedString{rbuf: rbuf}
+}
+
+// String aliases the Rust buffer, use Clone to keep the string past Release
+func (b *BorrowedString) String() string {
+	if b.released.Load() {
+		panic("BorrowedString used after Release")
+	}
+	return unsafe.String((*byte)(b.rbuf.Data()), b.rbuf.Len())
+}
+
+// Clone copies the string into Go memory
+func (b *BorrowedString) Clone() string {
+	return string([]byte(b.String()))
+}
+
+// Release frees the Rust buffer, calling it more than once has no effect
+func (b *BorrowedString) Release() {
+	if b != nil && !b.released.Swap(true) {
+		b.rbuf.Free()
+	}
+}
+
+// BorrowedBytes is a byte slice returned by Rust without copying it out of the Rust buffer
+// holding it. The buffer stays allocated until Release is called, after which the slice must no
+// longer be used. Returned by the functions listed in `borrowed_results`.
+type BorrowedBytes struct {
+	rbuf     RustBufferI
+	data     []byte
+	released atomic.Bool
+}
+
+func uniffiBorrowBytes(rbuf RustBufferI) *BorrowedBytes {
+	data := unsafe.Slice((*byte)(rbuf.Data()), rbuf.Len())
+	// Bytes are serialized with a length prefix, unlike strings
+	if len(data) < 4 || uint64(binary.BigEndian.Uint32(data)) != uint64(len(data)-4) {
+		rbuf.Free()
+		panic(&LiftError{TypeName: "[]byte", Reason: "length prefix does not match the buffer"})
+	}
+	return &BorrowedBytes{rbuf: rbuf, data: data[4:]}
+}
+
+// Bytes aliases the Rust buffer, use Clone to keep the bytes past Release
+func (b *BorrowedBytes) Bytes() []byte {
+	if b.released.Load() {
+		panic("BorrowedBytes used after Release")
+	}
+	return b.data[:len(b.data):len(b.data)]
+}
+
+// Clone copies the bytes into Go memory
+func (b *BorrowedBytes) Clone() []byte {
+	return append([]byte{}, b.Bytes()...)
+}
+
+// Release frees the Rust buffer, calling it more than once has no effect
+func (b *BorrowedBytes) Release() {
+	if b != nil && !b.released.Swap(true) {
+		b.rbuf.Free()
+	}
+}
+{%- endif %}
+
 func rustCall[U any](callback func(*C.RustCallStatus) U) U {
 	returnValue, err := rustCallWithError[error](nil, callback)
 	if err != nil {
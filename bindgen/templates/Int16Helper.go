@@ -10,15 +10,19 @@ func ({{ ffi_converter_name }}) Lower(value int16) C.int16_t {
 	return C.int16_t(value)
 }
 
-func ({{ ffi_converter_name }}) Write(writer io.Writer, value int16) {
+func ({{ ffi_converter_name }}) Write(writer *bytes.Buffer, value int16) {
 	writeInt16(writer, value)
 }
 
+func ({{ ffi_converter_name }}) AllocationSize(_ int16) uint64 {
+	return 2
+}
+
 func ({{ ffi_converter_name }}) Lift(value C.int16_t) int16 {
 	return int16(value)
 }
 
-func ({{ ffi_converter_name }}) Read(reader io.Reader) int16 {
+func ({{ ffi_converter_name }}) Read(reader *bytes.Reader) int16 {
 	return readInt16(reader)
 }
 
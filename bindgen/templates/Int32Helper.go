@@ -10,15 +10,19 @@ func ({{ ffi_converter_name }}) Lower(value int32) C.int32_t {
 	return C.int32_t(value)
 }
 
-func ({{ ffi_converter_name }}) Write(writer io.Writer, value int32) {
+func ({{ ffi_converter_name }}) Write(writer *bytes.Buffer, value int32) {
 	writeInt32(writer, value)
 }
 
+func ({{ ffi_converter_name }}) AllocationSize(_ int32) uint64 {
+	return 4
+}
+
 func ({{ ffi_converter_name }}) Lift(value C.int32_t) int32 {
 	return int32(value)
 }
 
-func ({{ ffi_converter_name }}) Read(reader io.Reader) int32 {
+func ({{ ffi_converter_name }}) Read(reader *bytes.Reader) int32 {
 	return readInt32(reader)
 }
 
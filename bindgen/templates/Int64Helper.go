@@ -10,15 +10,19 @@ func ({{ ffi_converter_name }}) Lower(value int64) C.int64_t {
 	return C.int64_t(value)
 }
 
-func ({{ ffi_converter_name }}) Write(writer io.Writer, value int64) {
+func ({{ ffi_converter_name }}) Write(writer *bytes.Buffer, value int64) {
 	writeInt64(writer, value)
 }
 
+func ({{ ffi_converter_name }}) AllocationSize(_ int64) uint64 {
+	return 8
+}
+
 func ({{ ffi_converter_name }}) Lift(value C.int64_t) int64 {
 	return int64(value)
 }
 
-func ({{ ffi_converter_name }}) Read(reader io.Reader) int64 {
+func ({{ ffi_converter_name }}) Read(reader *bytes.Reader) int64 {
 	return readInt64(reader)
 }
 
@@ -10,15 +10,19 @@ func ({{ ffi_converter_name }}) Lower(value int8) C.int8_t {
 	return C.int8_t(value)
 }
 
-func ({{ ffi_converter_name }}) Write(writer io.Writer, value int8) {
+func ({{ ffi_converter_name }}) Write(writer *bytes.Buffer, value int8) {
 	writeInt8(writer, value)
 }
 
+func ({{ ffi_converter_name }}) AllocationSize(_ int8) uint64 {
+	return 1
+}
+
 func ({{ ffi_converter_name }}) Lift(value C.int8_t) int8 {
 	return int8(value)
 }
 
-func ({{ ffi_converter_name }}) Read(reader io.Reader) int8 {
+func ({{ ffi_converter_name }}) Read(reader *bytes.Reader) int8 {
 	return readInt8(reader)
 }
 
@@ -0,0 +1,12 @@
+{{- self.add_import("log/slog") }}
+
+// Logged in place of fields configured as sensitive
+const uniffiRedacted = "[REDACTED]"
+
+// Logs the value itself rather than the pointer to it
+func uniffiLogOptional[T any](key string, value *T) slog.Attr {
+	if value == nil {
+		return slog.Any(key, nil)
+	}
+	return slog.Any(key, *value)
+}
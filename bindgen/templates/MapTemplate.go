@@ -13,9 +13,9 @@ func (c {{ ffi_converter_name }}) Lift(rb RustBufferI) {{ type_name }} {
 	return LiftFromRustBuffer[{{ type_name }}](c, rb)
 }
 
-func (_ {{ ffi_converter_name }}) Read(reader io.Reader) {{ type_name }} {
+func (_ {{ ffi_converter_name }}) Read(reader *bytes.Reader) {{ type_name }} {
 	result := make({{ type_name }})
-	length := readInt32(reader)
+	length := readLength(reader)
 	for i := int32(0); i < length; i++ {
 		key := {{ key_type|read_fn(ci) }}(reader)
 		value := {{ value_type|read_fn(ci) }}(reader)
@@ -32,9 +32,9 @@ func (c {{ ffi_converter_name }}) LowerExternal(value {{ type_name }}) ExternalC
 	return RustBufferFromC(LowerIntoRustBuffer[{{ type_name }}](c, value))
 }
 
-func (_ {{ ffi_converter_name }}) Write(writer io.Writer, mapValue {{ type_name }}) {
+func (_ {{ ffi_converter_name }}) Write(writer *bytes.Buffer, mapValue {{ type_name }}) {
 	if len(mapValue) > math.MaxInt32 {
-		panic("{{ type_name }} is too large to fit into Int32")
+		panic(&LowerError{Reason: "{{ type_name }} is too large to fit into Int32"})
 	}
 
 	writeInt32(writer, int32(len(mapValue)))
@@ -44,6 +44,14 @@ func (_ {{ ffi_converter_name }}) Write(writer io.Writer, mapValue {{ type_name
 	}
 }
 
+func (_ {{ ffi_converter_name }}) AllocationSize(mapValue {{ type_name }}) uint64 {
+	size := uint64(4)
+	for key, value := range mapValue {
+		size += {{ key_type|allocation_size_fn(ci) }}(key) + {{ value_type|allocation_size_fn(ci) }}(value)
+	}
+	return size
+}
+
 type {{ ffi_destroyer_name }} struct {}
 
 func (_ {{ ffi_destroyer_name }}) Destroy(mapValue {{ type_name }}) {
@@ -1,34 +1,134 @@
+{%- let initialization_fns = self.initialization_fns() %}
+{%- if config.deferred_initialization() %}
+var uniffiInitOnce sync.Once
+var uniffiInitErr error
+
+// Initialize checks that the loaded Rust library matches these bindings and registers callback
+// interfaces with it. Every function of this package calls it on first use and returns its
+// error, or panics with it when the function has no error result. Call it up front to handle a
+// mismatched library gracefully. Later calls return the result of the first one.
+func Initialize() error {
+	uniffiInitOnce.Do(func() {
+		if err := uniffiCheckChecksums(); err != nil {
+			uniffiInitErr = err
+			return
+		}
+		{%- for func in initialization_fns %}
+		{{ func }}()
+		{%- endfor %}
+	})
+	return uniffiInitErr
+}
+{%- else %}
 func init() {
-        {% let initialization_fns = self.initialization_fns() %}
         {% for func in initialization_fns -%}
         {{ func }}();
         {% endfor -%}
 
-	uniffiCheckChecksums()
+	if err := uniffiCheckChecksums(); err != nil {
+		panic(err)
+	}
+}
+{%- endif %}
+
+// ContractMismatchError means that the loaded Rust library was built from a different interface
+// than these bindings. If this happens try cleaning and rebuilding your project.
+type ContractMismatchError struct {
+	// Namespace of the bindings
+	Namespace string
+	// UniFFI contract versions of the bindings and of the library, checksums are only compared
+	// when these match
+	BindingsContractVersion    int
+	ScaffoldingContractVersion int
+	// API items whose checksum does not match these bindings
+	MismatchedChecksums []ChecksumMismatch
+}
+
+func (e *ContractMismatchError) Error() string {
+	if e.BindingsContractVersion != e.ScaffoldingContractVersion {
+		return fmt.Sprintf("%s: UniFFI contract version mismatch: bindings have %d, library has %d",
+			e.Namespace, e.BindingsContractVersion, e.ScaffoldingContractVersion)
+	}
+	mismatched := make([]string, len(e.MismatchedChecksums))
+	for i, m := range e.MismatchedChecksums {
+		mismatched[i] = m.String()
+	}
+	return fmt.Sprintf("%s: UniFFI API checksum mismatch: %s", e.Namespace, strings.Join(mismatched, "; "))
+}
+
+// ChecksumMismatch describes an item of the API that changed in the Rust library
+type ChecksumMismatch struct {
+	// FFI checksum function of the item
+	Symbol string
+	// "function", "constructor", "method" or "callback method"
+	Kind string
+	// Go type of constructors and methods, empty for functions
+	Object string
+	// Go name of the item
+	Name string
+	// Arguments and results these bindings expect, e.g. "(by uint32) (uint32, error)"
+	Signature string
+}
+
+func (m ChecksumMismatch) String() string {
+	if m.Kind == "method" || m.Kind == "callback method" {
+		return fmt.Sprintf("%s %s.%s%s", m.Kind, m.Object, m.Name, m.Signature)
+	}
+	return fmt.Sprintf("%s %s%s", m.Kind, m.Name, m.Signature)
 }
 
+// Checksum of an API item, as generated into these bindings and as read from the library
+type uniffiChecksum struct {
+	item     ChecksumMismatch
+	expected uint16
+	read     func() uint16
+}
 
-func uniffiCheckChecksums() {
+func uniffiCheckChecksums() error {
 	// Get the bindings contract version from our ComponentInterface
 	bindingsContractVersion := {{ ci.uniffi_contract_version() }}
 	// Get the scaffolding contract version by calling the into the dylib
 	scaffoldingContractVersion := rustCall(func(_uniffiStatus *C.RustCallStatus) C.uint32_t {
 		return C.{{ ci.ffi_uniffi_contract_version().name() }}()
 	})
+	mismatch := &ContractMismatchError{
+		Namespace:                  "{{ ci.namespace() }}",
+		BindingsContractVersion:    bindingsContractVersion,
+		ScaffoldingContractVersion: int(scaffoldingContractVersion),
+	}
 	if bindingsContractVersion != int(scaffoldingContractVersion) {
-		// If this happens try cleaning and rebuilding your project
-		panic("{{ ci.namespace() }}: UniFFI contract version mismatch")
-	}
-	
-	{%- for (name, expected_checksum) in ci.iter_checksums() %}
-	{
-	checksum := rustCall(func(_uniffiStatus *C.RustCallStatus) C.uint16_t {
-		return C.{{ name }}()
+		return mismatch
+	}
+	return uniffiCollectChecksumMismatches(mismatch, []uniffiChecksum{
+		{%- for item in self.checksum_items() %}
+		{
+			item: ChecksumMismatch{
+				Symbol:    "{{ item.symbol }}",
+				Kind:      "{{ item.kind }}",
+				Object:    "{{ item.object }}",
+				Name:      "{{ item.name }}",
+				Signature: "{{ item.signature }}",
+			},
+			expected: {{ item.checksum }},
+			read: func() uint16 {
+				return uint16(rustCall(func(_uniffiStatus *C.RustCallStatus) C.uint16_t {
+					return C.{{ item.symbol }}()
+				}))
+			},
+		},
+		{%- endfor %}
 	})
-	if checksum != {{ expected_checksum }} {
-		// If this happens try cleaning and rebuilding your project
-		panic("{{ ci.namespace() }}: {{ name }}: UniFFI API checksum mismatch")
+}
+
+// Every checksum is compared, so that the error lists all mismatched items at once
+func uniffiCollectChecksumMismatches(mismatch *ContractMismatchError, checksums []uniffiChecksum) error {
+	for _, checksum := range checksums {
+		if checksum.read() != checksum.expected {
+			mismatch.MismatchedChecksums = append(mismatch.MismatchedChecksums, checksum.item)
+		}
 	}
+	if len(mismatch.MismatchedChecksums) > 0 {
+		return mismatch
 	}
-	{%- endfor %}
+	return nil
 }
@@ -5,7 +5,14 @@
 // Below is an implementation of synchronization requirements outlined in the link.
 // https://github.com/mozilla/uniffi-rs/blob/0dc031132d9493ca812c3af6e7dd60ad2ea95bf0/uniffi_bindgen/src/bindings/kotlin/templates/ObjectRuntime.kt#L31
 
+{{- self.add_import("errors") }}
 {{- self.add_import("math") }}
+{{- self.add_import("os") }}
+{{- self.add_import("runtime") }}
+{{- self.add_import("sort") }}
+{{- self.add_import("strconv") }}
+{{- self.add_import("strings") }}
+{{- self.add_import("sync") }}
 {{- self.add_import("sync/atomic") }}
 
 type FfiObject struct {
@@ -14,9 +21,14 @@ type FfiObject struct {
 	cloneFunction func(C.uint64_t, *C.RustCallStatus) C.uint64_t
 	freeFunction func(C.uint64_t, *C.RustCallStatus)
 	destroyed atomic.Bool
+	trackingId uint64
+	{%- if config.runtime_cleanup() %}
+	cleanup runtime.Cleanup
+	{%- endif %}
 }
 
 func newFfiObject(
+	typeName string,
 	handle C.uint64_t,
 	cloneFunction func(C.uint64_t, *C.RustCallStatus) C.uint64_t,
 	freeFunction func(C.uint64_t, *C.RustCallStatus),
@@ -25,17 +37,43 @@ func newFfiObject(
 		handle: handle,
 		cloneFunction: cloneFunction,
 		freeFunction: freeFunction,
+		trackingId: uniffiObjectTracker.add(typeName),
 	}
 }
 
+// ErrObjectDestroyed is reported when a method is called on an object that can no longer be
+// used, either because it was destroyed or because too many calls are in progress at once.
+var ErrObjectDestroyed = errors.New("object has already been destroyed")
+
+type uniffiCallCounterOverflowError struct {
+	debugName string
+}
+
+func (e uniffiCallCounterOverflowError) Error() string {
+	return fmt.Sprintf("%v object call counter would overflow", e.debugName)
+}
+
+func (e uniffiCallCounterOverflowError) Is(target error) bool {
+	return target == ErrObjectDestroyed
+}
+
 func (ffiObject *FfiObject)incrementPointer(debugName string) C.uint64_t {
+	handle, err := ffiObject.tryIncrementPointer(debugName)
+	if err != nil {
+		panic(err)
+	}
+	return handle
+}
+
+// Same as incrementPointer, but returns an error matching ErrObjectDestroyed instead of panicking
+func (ffiObject *FfiObject)tryIncrementPointer(debugName string) (C.uint64_t, error) {
 	for {
 		counter := ffiObject.callCounter.Load()
 		if counter <= -1 {
-			panic(fmt.Errorf("%v object has already been destroyed", debugName))
+			return 0, fmt.Errorf("%v %w", debugName, ErrObjectDestroyed)
 		}
 		if counter == math.MaxInt64 {
-			panic(fmt.Errorf("%v object call counter would overflow", debugName))
+			return 0, uniffiCallCounterOverflowError{debugName}
 		}
 		if ffiObject.callCounter.CompareAndSwap(counter, counter + 1) {
 			break
@@ -44,6 +82,29 @@ func (ffiObject *FfiObject)incrementPointer(debugName string) C.uint64_t {
 
 	return rustCall(func(status *C.RustCallStatus) C.uint64_t {
 		return ffiObject.cloneFunction(ffiObject.handle, status)
+	}), nil
+}
+
+// Returns a new handle to the Rust object, owned by the caller. Lowering hands such clones
+// over to Rust, which then keeps the object alive on its own. The call counter is held while
+// cloning, so a concurrent destroy either happens before (and panics here) or after the clone
+// exists, but never frees the object in the middle. Lowering a destroyed object raises a
+// *LowerError matching ErrObjectDestroyed.
+func (ffiObject *FfiObject)cloneHandle(debugName string) C.uint64_t {
+	handle, err := ffiObject.tryIncrementPointer(debugName)
+	if err != nil {
+		panic(&LowerError{Reason: err.Error(), err: err})
+	}
+	ffiObject.decrementPointer()
+	return handle
+}
+
+// Frees a handle returned by incrementPointer or cloneHandle that never reached Rust, e.g.
+// because a later argument of the call failed to lower
+func (ffiObject *FfiObject)freeHandle(handle C.uint64_t) {
+	rustCall(func(status *C.RustCallStatus) int32 {
+		ffiObject.freeFunction(handle, status)
+		return 0
 	})
 }
 
@@ -54,7 +115,20 @@ func (ffiObject *FfiObject)decrementPointer() {
 }
 
 func (ffiObject *FfiObject)destroy() {
+	ffiObject.release(false)
+}
+
+// Same as destroy, but the object is reported as leaked when tracking objects
+func (ffiObject *FfiObject)finalize() {
+	ffiObject.release(true)
+}
+
+func (ffiObject *FfiObject)release(finalized bool) {
 	if ffiObject.destroyed.CompareAndSwap(false, true) {
+		{%- if config.runtime_cleanup() %}
+		ffiObject.cleanup.Stop()
+		{%- endif %}
+		uniffiObjectTracker.remove(ffiObject.trackingId, finalized)
 		if ffiObject.callCounter.Add(-1) == -1 {
 			ffiObject.freeRustArcPtr()
 		}
@@ -70,3 +144,208 @@ func (ffiObject *FfiObject)freeRustArcPtr() {
 		return 0
 	})
 }
+
+{%- if config.runtime_cleanup() %}
+
+// Everything needed to free the Rust object once its Go wrapper is unreachable. The cleanup
+// must not reference the wrapper itself, otherwise it would never become unreachable.
+type uniffiUnreachableHandle struct {
+	handle       C.uint64_t
+	freeFunction func(C.uint64_t, *C.RustCallStatus)
+	trackingId   uint64
+}
+
+func uniffiAddCleanup[T any](owner *T, ffiObject *FfiObject) {
+	ffiObject.cleanup = runtime.AddCleanup(owner, uniffiReleaseQueue.push, uniffiUnreachableHandle{
+		handle:       ffiObject.handle,
+		freeFunction: ffiObject.freeFunction,
+		trackingId:   ffiObject.trackingId,
+	})
+}
+
+// Cleanups only queue the handle, the Rust objects are freed in batches on a goroutine of
+// their own. This way a slow Rust `Drop` does not hold up other cleanups in the process.
+var uniffiReleaseQueue = &uniffiReleaseQueueState{
+	signal: make(chan struct{}, 1),
+}
+
+type uniffiReleaseQueueState struct {
+	once    sync.Once
+	lock    sync.Mutex
+	pending []uniffiUnreachableHandle
+	signal  chan struct{}
+}
+
+func (q *uniffiReleaseQueueState) push(handle uniffiUnreachableHandle) {
+	q.once.Do(func() { go q.run() })
+
+	q.lock.Lock()
+	q.pending = append(q.pending, handle)
+	q.lock.Unlock()
+
+	select {
+	case q.signal <- struct{}{}:
+	default:
+	}
+}
+
+func (q *uniffiReleaseQueueState) run() {
+	var batch []uniffiUnreachableHandle
+	for range q.signal {
+		q.lock.Lock()
+		batch, q.pending = q.pending, batch[:0]
+		q.lock.Unlock()
+
+		for i, unreachable := range batch {
+			uniffiObjectTracker.remove(unreachable.trackingId, true)
+			if unreachable.handle != 0 {
+				rustCall(func(status *C.RustCallStatus) int32 {
+					unreachable.freeFunction(unreachable.handle, status)
+					return 0
+				})
+			}
+			batch[i] = uniffiUnreachableHandle{}
+		}
+	}
+}
+{%- endif %}
+
+// LiveObject describes a Rust object whose Go wrapper was created while object tracking
+// was enabled.
+type LiveObject struct {
+	// Name of the object type, as in `LiveObjectCount`
+	TypeName string
+	// Stack of the goroutine that created the Go wrapper
+	Stack string
+
+	id uint64
+}
+
+// Object tracking is meant for tests and debugging, it records the creation stack of
+// every object and is off by default. It can be enabled at startup by setting the
+// UNIFFI_GO_TRACK_OBJECTS environment variable to a true value, e.g. `1`.
+var uniffiObjectTracker = newUniffiObjectTracker(os.Getenv("UNIFFI_GO_TRACK_OBJECTS"))
+
+type uniffiObjectTrackerState struct {
+	enabled   atomic.Bool
+	nextId    atomic.Uint64
+	lock      sync.Mutex
+	live      map[uint64]LiveObject
+	finalized []LiveObject
+}
+
+func newUniffiObjectTracker(env string) *uniffiObjectTrackerState {
+	tracker := &uniffiObjectTrackerState{
+		live: map[uint64]LiveObject{},
+	}
+	enabled, _ := strconv.ParseBool(env)
+	tracker.enabled.Store(enabled)
+	return tracker
+}
+
+// SetObjectTracking turns object tracking on or off. Only objects created while tracking
+// is enabled are reported by LiveObjects, LiveObjectCount and ReportLeaks.
+func SetObjectTracking(enabled bool) {
+	uniffiObjectTracker.enabled.Store(enabled)
+}
+
+// LiveObjects returns the tracked objects that have not been destroyed yet, oldest first.
+func LiveObjects() []LiveObject {
+	uniffiObjectTracker.lock.Lock()
+	defer uniffiObjectTracker.lock.Unlock()
+
+	objects := make([]LiveObject, 0, len(uniffiObjectTracker.live))
+	for _, object := range uniffiObjectTracker.live {
+		objects = append(objects, object)
+	}
+	sort.Slice(objects, func(i, j int) bool {
+		return objects[i].id < objects[j].id
+	})
+	return objects
+}
+
+// LiveObjectCount returns the number of tracked objects of the given type that have not
+// been destroyed yet. An empty typeName counts objects of all types.
+func LiveObjectCount(typeName string) int {
+	uniffiObjectTracker.lock.Lock()
+	defer uniffiObjectTracker.lock.Unlock()
+
+	count := 0
+	for _, object := range uniffiObjectTracker.live {
+		if typeName == "" || object.TypeName == typeName {
+			count++
+		}
+	}
+	return count
+}
+
+// ReportLeaks writes the tracked objects that are still alive, as well as those that were
+// released by the garbage collector instead of Destroy, to w. It returns the number of
+// objects reported. Objects released by the garbage collector are only reported once.
+func ReportLeaks(w io.Writer) int {
+	live := LiveObjects()
+
+	uniffiObjectTracker.lock.Lock()
+	finalized := uniffiObjectTracker.finalized
+	uniffiObjectTracker.finalized = nil
+	uniffiObjectTracker.lock.Unlock()
+
+	for _, object := range live {
+		fmt.Fprintf(w, "%s was never destroyed, created at:\n%s", object.TypeName, object.Stack)
+	}
+	for _, object := range finalized {
+		fmt.Fprintf(w, "%s was released by the garbage collector, created at:\n%s", object.TypeName, object.Stack)
+	}
+	return len(live) + len(finalized)
+}
+
+// Returns 0 if tracking is disabled
+func (t *uniffiObjectTrackerState) add(typeName string) uint64 {
+	if !t.enabled.Load() {
+		return 0
+	}
+
+	id := t.nextId.Add(1)
+	object := LiveObject{
+		TypeName: typeName,
+		Stack:    uniffiCallerStack(),
+		id:       id,
+	}
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.live[id] = object
+	return id
+}
+
+func (t *uniffiObjectTrackerState) remove(id uint64, finalized bool) {
+	if id == 0 {
+		return
+	}
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if object, ok := t.live[id]; ok {
+		delete(t.live, id)
+		if finalized {
+			t.finalized = append(t.finalized, object)
+		}
+	}
+}
+
+func uniffiCallerStack() string {
+	pcs := make([]uintptr, 32)
+	// Skip runtime.Callers, this function, the tracker and newFfiObject
+	n := runtime.Callers(4, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+
+	var stack strings.Builder
+	for {
+		frame, more := frames.Next()
+		fmt.Fprintf(&stack, "%s\n\t%s:%d\n", frame.Function, frame.File, frame.Line)
+		if !more {
+			break
+		}
+	}
+	return stack.String()
+}
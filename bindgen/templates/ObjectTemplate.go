@@ -7,6 +7,11 @@
 {%- let obj = ci.get_object_definition(name).expect("missing obj") %}
 {%- let (interface_name, impl_name) = obj|object_names %}
 {%- let impl_type_name = format!("*{impl_name}") %}
+{%- let takes_ctx = obj.has_callback_interface() && config.takes_context(name) %}
+{#- Go implementations of trait interfaces keep their signatures #}
+{%- let destroyed_errors = config.use_after_destroy_errors() && !obj.has_callback_interface() %}
+{%- let method_errors = (config.use_after_destroy_errors() || config.recovered_errors()) && !obj.has_callback_interface() %}
+{%- let fn_errors = config.recovered_errors() %}
 
 {%- if self.include_once_check("ObjectRuntime.go") %}{% include "ObjectRuntime.go" %}{% endif %}
 
@@ -14,7 +19,7 @@
 type {{ interface_name }} interface {
 	{%- for func in obj.methods() -%}
 	{%- call go::docstring(func, 1) %}
-	{{ func.name()|fn_name }}({%- call go::arg_list_decl(func) -%}) {% call go::return_type_decl(func) %}
+	{{ func.name()|fn_name }}({%- call go::interface_arg_list_decl(func, takes_ctx) -%}) {% call go::method_return_type_decl(func, method_errors) %}
 	{%- endfor %}
 }
 
@@ -26,41 +31,105 @@ type {{ impl_name }} struct {
 {%- match obj.primary_constructor() %}
 {%- when Some with (cons) %}
 {%- call go::docstring(cons, 0) %}
-func New{{ impl_name }}({% call go::arg_list_decl(cons) -%}) {% call go::return_type_decl(cons) %} {
+func New{{ impl_name }}({% call go::arg_list_decl(cons) -%}) {% call go::fn_return_type_decl(cons, fn_errors) %} {
+	{%- call go::recover_rust_panic(cons, fn_errors) %}
 	{%- if cons.is_async() %}
-	{% call go::async_ffi_call_binding(cons, "") %}
+	{% call go::async_ffi_call_binding(cons, "", fn_errors) %}
 	{%- else %}
-	{% call go::ffi_call_binding(cons, "") %}
+	{% call go::ffi_call_binding(cons, "", fn_errors) %}
 	{%- endif %}
 }
+{%- if cons.is_async() %}
+{% call go::async_ctx_docstring(format!("New{impl_name}")) %}
+func New{{ impl_name }}Ctx({% call go::async_ctx_arg_list_decl(cons) -%}) {% call go::fn_return_type_decl(cons, true) %} {
+	{%- call go::recover_rust_panic(cons, true) %}
+	{% call go::async_ctx_ffi_call_binding(cons, "") %}
+}
+{% call go::async_future_docstring(format!("New{impl_name}")) %}
+func New{{ impl_name }}Future({% call go::arg_list_decl(cons) -%}) {% call go::async_future_return_type(cons) %} {
+	{% call go::async_future_ffi_call_binding(cons, "") %}
+}
+{%- endif %}
+{%- let primary_name = format!("New{impl_name}") %}
+{%- call go::with_options(cons, primary_name, primary_name, fn_errors) %}
 {%- when None %}
 {%- endmatch %}
 
 {% for cons in obj.alternate_constructors() -%}
 {%- call go::docstring(cons, 0) %}
-func {{ impl_name }}{{ cons.name()|fn_name }}({% call go::arg_list_decl(cons) %}) {% call go::return_type_decl(cons) %} {
+func {{ impl_name }}{{ cons.name()|fn_name }}({% call go::arg_list_decl(cons) %}) {% call go::fn_return_type_decl(cons, fn_errors) %} {
+	{%- call go::recover_rust_panic(cons, fn_errors) %}
 	{%- if cons.is_async() %}
-	{% call go::async_ffi_call_binding(cons, "") %}
+	{% call go::async_ffi_call_binding(cons, "", fn_errors) %}
 	{%- else %}
-	{% call go::ffi_call_binding(cons, "") %}
+	{% call go::ffi_call_binding(cons, "", fn_errors) %}
 	{%- endif %}
 }
+{%- if cons.is_async() %}
+{%- let cons_name = cons.name()|fn_name %}
+{% call go::async_ctx_docstring(format!("{impl_name}{cons_name}")) %}
+func {{ impl_name }}{{ cons.name()|fn_name }}Ctx({% call go::async_ctx_arg_list_decl(cons) %}) {% call go::fn_return_type_decl(cons, true) %} {
+	{%- call go::recover_rust_panic(cons, true) %}
+	{% call go::async_ctx_ffi_call_binding(cons, "") %}
+}
+{% call go::async_future_docstring(format!("{impl_name}{cons_name}")) %}
+func {{ impl_name }}{{ cons.name()|fn_name }}Future({% call go::arg_list_decl(cons) %}) {% call go::async_future_return_type(cons) %} {
+	{% call go::async_future_ffi_call_binding(cons, "") %}
+}
+{%- endif %}
+{%- let alternate_suffix = cons.name()|fn_name %}
+{%- let alternate_name = format!("{impl_name}{alternate_suffix}") %}
+{%- call go::with_options(cons, alternate_name, alternate_name, fn_errors) %}
 {% endfor %}
 
 {% for func in obj.methods() -%}
 
 {%- call go::docstring(func, 0) %}
-func (_self {{ impl_type_name }}) {{ func.name()|fn_name }}({%- call go::arg_list_decl(func) -%}) {% call go::return_type_decl(func) %} {
-	_pointer := _self.ffiObject.incrementPointer("{{ type_name }}")
-	defer _self.ffiObject.decrementPointer()
+{%- if takes_ctx && func.is_async() %}
+{{- self.add_import("errors") }}
+func (_self {{ impl_type_name }}) {{ func.name()|fn_name }}({%- call go::async_ctx_arg_list_decl(func) -%}) {% call go::return_type_decl(func) %} {
+	{%- call go::async_ctx_delegate(func) %}
+}
+{%- else %}
+func (_self {{ impl_type_name }}) {{ func.name()|fn_name }}({%- call go::arg_list_decl(func) -%}) {% call go::fn_return_type_decl(func, method_errors) %} {
+	{%- call go::recover_rust_panic(func, method_errors) %}
+	{%- call go::acquire_object_pointer(func, type_name, destroyed_errors) %}
 	{%- if func.is_async() %}
-	{% call go::async_ffi_call_binding(func, "_pointer") %}
+	{% call go::async_ffi_call_binding(func, "_pointer", method_errors) %}
 	{%- else %}
-	{% call go::ffi_call_binding(func, "_pointer") %}
+	{% call go::ffi_call_binding(func, "_pointer", method_errors) %}
 	{%- endif %}
 }
+{%- endif %}
+{%- if func.is_async() %}
+{% call go::async_ctx_docstring(func.name()|fn_name) %}
+func (_self {{ impl_type_name }}) {{ func.name()|fn_name }}Ctx({%- call go::async_ctx_arg_list_decl(func) -%}) {% call go::fn_return_type_decl(func, true) %} {
+	{%- call go::recover_rust_panic(func, true) %}
+	{%- call go::acquire_object_pointer(func, type_name, destroyed_errors) %}
+	{% call go::async_ctx_ffi_call_binding(func, "_pointer") %}
+}
+{% call go::async_future_docstring(func.name()|fn_name) %}
+func (_self {{ impl_type_name }}) {{ func.name()|fn_name }}Future({%- call go::arg_list_decl(func) -%}) {% call go::async_future_return_type(func) %} {
+	{%- if destroyed_errors %}
+	_pointer, _uniffiPointerErr := _self.ffiObject.tryIncrementPointer("{{ type_name }}")
+	if _uniffiPointerErr != nil {
+		return newFailedFuture[{% call go::async_future_value_type(func) %}](_uniffiPointerErr)
+	}
+	{%- else %}
+	_pointer := _self.ffiObject.incrementPointer("{{ type_name }}")
+	{%- endif %}
+	defer _self.ffiObject.decrementPointer()
+	{% call go::async_future_ffi_call_binding(func, "_pointer") %}
+}
+{%- endif %}
+{%- let method_name = func.name()|fn_name %}
+{%- call go::with_options(func, format!("{impl_name}{method_name}"), method_name, method_errors, impl_type_name, takes_ctx) %}
 {% endfor %}
 
+{%- if let Some(stream) = config.stream(name) %}
+{%- include "StreamTemplate.go" %}
+{%- endif %}
+
 {%- for tm in obj.uniffi_traits() -%}
 {%- match tm %}
 {%- when UniffiTrait::Display { fmt } %}
@@ -108,9 +177,18 @@ func (_self {{ impl_type_name }}) Cmp(other {{ type_name }}) int8 {
 {% endfor -%}
 
 func (object {{ impl_type_name }}) Destroy() {
+	{%- if !config.runtime_cleanup() %}
 	runtime.SetFinalizer(object, nil)
+	{%- endif %}
 	object.ffiObject.destroy()
 }
+{%- if !config.runtime_cleanup() %}
+
+// Called by the garbage collector for objects that were never destroyed
+func (object {{ impl_type_name }}) finalize() {
+	object.ffiObject.finalize()
+}
+{%- endif %}
 
 type {{ ffi_converter_name }} struct {
 	{%- if obj.has_callback_interface() %}
@@ -150,6 +228,7 @@ func (c {{ ffi_converter_name }}) Lift(handle C.uint64_t) {{ type_name }} {
 		// Rust-generated handle (even), construct a new object wrapping the handle
 		result := &{{ impl_name }} {
 			newFfiObject(
+				"{{ canonical_type_name }}",
 				handle,
 				func(handle C.uint64_t, status *C.RustCallStatus) C.uint64_t {
 					return C.{{ obj.ffi_object_clone().name() }}(handle, status)
@@ -159,7 +238,11 @@ func (c {{ ffi_converter_name }}) Lift(handle C.uint64_t) {{ type_name }} {
 				},
 			),
 		}
-		runtime.SetFinalizer(result, ({{ impl_type_name }}).Destroy)
+		{%- if config.runtime_cleanup() %}
+		uniffiAddCleanup(result, &result.ffiObject)
+		{%- else %}
+		runtime.SetFinalizer(result, ({{ impl_type_name }}).finalize)
+		{%- endif %}
 		return result
 	} else {
 		// Go-generated handle (odd), retrieve from the handle map
@@ -173,6 +256,7 @@ func (c {{ ffi_converter_name }}) Lift(handle C.uint64_t) {{ type_name }} {
 	{%- else %}
 	result := &{{ impl_name }} {
 		newFfiObject(
+			"{{ canonical_type_name }}",
 			handle,
 			func(handle C.uint64_t, status *C.RustCallStatus) C.uint64_t {
 				return C.{{ obj.ffi_object_clone().name() }}(handle, status)
@@ -182,38 +266,56 @@ func (c {{ ffi_converter_name }}) Lift(handle C.uint64_t) {{ type_name }} {
 			},
 		),
 	}
-	runtime.SetFinalizer(result, ({{ impl_type_name }}).Destroy)
+	{%- if config.runtime_cleanup() %}
+	uniffiAddCleanup(result, &result.ffiObject)
+	{%- else %}
+	runtime.SetFinalizer(result, ({{ impl_type_name }}).finalize)
+	{%- endif %}
 	return result
 	{%- endif %}
 }
 
-func (c {{ ffi_converter_name }}) Read(reader io.Reader) {{ type_name }} {
+func (c {{ ffi_converter_name }}) Read(reader *bytes.Reader) {{ type_name }} {
 	return c.Lift(C.uint64_t(readUint64(reader)))
 }
 
+// Lowered handles are owned by Rust, destroying value afterwards does not affect them
 func (c {{ ffi_converter_name }}) Lower(value {{ type_name }}) C.uint64_t {
-	// TODO: this is bad - all synchronization from ObjectRuntime.go is discarded here,
-	// because the handle will be decremented immediately after this function returns,
-	// and someone will be left holding onto a non-locked handle.
 	{%- if obj.has_callback_interface() %}
 	if val, ok := value.({{ impl_type_name }}); ok {
 		// Rust-backed object, clone the handle
-		handle := val.ffiObject.incrementPointer("{{ type_name }}")
-		defer val.ffiObject.decrementPointer()
-		return handle
+		return val.ffiObject.cloneHandle("{{ type_name }}")
 	} else {
 		// Go-backed object, insert into handle map
 		return C.uint64_t(c.handleMap.insert(value))
 	}
 	{%- else %}
-	handle := value.ffiObject.incrementPointer("{{ type_name }}")
-	defer value.ffiObject.decrementPointer()
-	return handle
+	return value.ffiObject.cloneHandle("{{ type_name }}")
+	{%- endif %}
+}
+
+func (c {{ ffi_converter_name }}) Write(writer *bytes.Buffer, value {{ type_name }}) {
+	handle := uint64(c.Lower(value))
+	uniffiTrackWrittenHandle(writer, handle, c.release)
+	writeUint64(writer, handle)
+}
+
+// Releases a lowered handle that never reached Rust
+func (c {{ ffi_converter_name }}) release(handle uint64) {
+	{%- if obj.has_callback_interface() %}
+	if handle & 1 == 1 {
+		c.handleMap.remove(handle)
+		return
+	}
 	{%- endif %}
+	rustCall(func(status *C.RustCallStatus) int32 {
+		C.{{ obj.ffi_object_free().name() }}(C.uint64_t(handle), status)
+		return 0
+	})
 }
 
-func (c {{ ffi_converter_name }}) Write(writer io.Writer, value {{ type_name }}) {
-	writeUint64(writer, uint64(c.Lower(value)))
+func (c {{ ffi_converter_name }}) AllocationSize(_ {{ type_name }}) uint64 {
+	return 8
 }
 
 func LiftFromExternal{{ canonical_type_name }}(handle uint64) {{ type_name }} {
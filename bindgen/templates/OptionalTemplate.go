@@ -10,7 +10,7 @@ func (c {{ ffi_converter_name }}) Lift(rb RustBufferI) {{ type_name }} {
 	return LiftFromRustBuffer[{{ type_name }}](c, rb)
 }
 
-func (_ {{ ffi_converter_name }}) Read(reader io.Reader) {{ type_name }} {
+func (_ {{ ffi_converter_name }}) Read(reader *bytes.Reader) {{ type_name }} {
 	if readInt8(reader) == 0 {
 		return nil
 	}
@@ -26,7 +26,7 @@ func (c {{ ffi_converter_name }}) LowerExternal(value {{ type_name }}) ExternalC
 	return RustBufferFromC(LowerIntoRustBuffer[{{ type_name }}](c, value))
 }
 
-func (_ {{ ffi_converter_name }}) Write(writer io.Writer, value {{ type_name }}) {
+func (_ {{ ffi_converter_name }}) Write(writer *bytes.Buffer, value {{ type_name }}) {
 	if value == nil {
 		writeInt8(writer, 0)
 	} else {
@@ -35,6 +35,13 @@ func (_ {{ ffi_converter_name }}) Write(writer io.Writer, value {{ type_name }})
 	}
 }
 
+func (_ {{ ffi_converter_name }}) AllocationSize(value {{ type_name }}) uint64 {
+	if value == nil {
+		return 1
+	}
+	return 1 + {{ inner_type|allocation_size_fn(ci) }}(*value)
+}
+
 type {{ ffi_destroyer_name }} struct {}
 
 func (_ {{ ffi_destroyer_name }}) Destroy(value {{ type_name }}) {
@@ -3,6 +3,7 @@
  * file, You can obtain one at http://mozilla.org/MPL/2.0/. */#}
 
 {%- let rec = ci.get_record_definition(name).expect("missing record") %}
+{%- let fn_errors = config.recovered_errors() %}
 
 {%- call go::docstring(rec, 0) %}
 type {{ type_name }} struct {
@@ -17,7 +18,41 @@ func (r *{{ type_name }}) Destroy() {
 		{{ field|destroy_fn(ci) }}(r.{{ field.name()|field_name }});
 	{%- endfor %}
 }
+{%- if let Some(required) = rec|required_fields(ci) %}
 
+// New{{ type_name }}Default fills the fields of {{ type_name }} that have a default in Rust, and
+// sets the others to the given values.
+func New{{ type_name }}Default(
+	{%- for field in required -%}
+	{{ field.name()|var_name }} {{ field|type_name(ci) }}
+	{%- if !loop.last %}, {% endif -%}
+	{%- endfor -%}
+) {{ type_name }} {
+	return {{ type_name }}{
+		{%- for field in rec.fields() %}
+		{%- match field.default_value() %}
+		{%- when Some with (value) %}
+		{{ field.name()|field_name }}: {{ value|default_value(field, ci) }},
+		{%- when None %}
+		{{ field.name()|field_name }}: {{ field.name()|var_name }},
+		{%- endmatch %}
+		{%- endfor %}
+	}
+}
+{%- endif %}
+{%- if config.log_valuer() %}
+{%- if self.include_once_check("LogValuer.go") %}{% include "LogValuer.go" %}{% endif %}
+
+func (r {{ type_name }}) LogValue() slog.Value {
+	return slog.GroupValue(
+	{%- for field in rec.fields() %}
+		{%- call go::log_attr(field, field.name(), "r", field.name()|field_name, config.sensitive_field(name, field.name())) %}
+	{%- endfor %}
+	)
+}
+{%- endif %}
+
+{%- let release_self = type_|release_lowered("_selfBuf", ci) %}
 {%- let trait_methods = rec.uniffi_trait_methods() %}
 {%- let receiver_type = type_name %}
 {%- let self_binding = "_selfBuf" %}
@@ -25,14 +60,28 @@ func (r *{{ type_name }}) Destroy() {
 
 {%- for meth in rec.methods() %}
 {%- call go::docstring(meth, 0) %}
-func (_self {{ type_name }}) {{ meth.name()|fn_name }}({%- call go::arg_list_decl(meth) -%}) {% call go::return_type_decl(meth) %} {
+func (_self {{ type_name }}) {{ meth.name()|fn_name }}({%- call go::arg_list_decl(meth) -%}) {% call go::fn_return_type_decl(meth, fn_errors) %} {
+	{%- call go::recover_rust_panic(meth, fn_errors) %}
 	_selfBuf := {{ ffi_converter_instance }}.Lower(_self)
 	{% if meth.is_async() %}
-	{% call go::async_ffi_call_binding(meth, "_selfBuf") %}
+	{% call go::async_ffi_call_binding(meth, "_selfBuf", fn_errors, release_self) %}
 	{% else %}
-	{% call go::ffi_call_binding(meth, "_selfBuf") %}
+	{% call go::ffi_call_binding(meth, "_selfBuf", fn_errors, release_self) %}
 	{% endif %}
 }
+{%- if meth.is_async() %}
+{% call go::async_ctx_docstring(meth.name()|fn_name) %}
+func (_self {{ type_name }}) {{ meth.name()|fn_name }}Ctx({%- call go::async_ctx_arg_list_decl(meth) -%}) {% call go::fn_return_type_decl(meth, true) %} {
+	{%- call go::recover_rust_panic(meth, true) %}
+	_selfBuf := {{ ffi_converter_instance }}.Lower(_self)
+	{% call go::async_ctx_ffi_call_binding(meth, "_selfBuf", release_self) %}
+}
+{% call go::async_future_docstring(meth.name()|fn_name) %}
+func (_self {{ type_name }}) {{ meth.name()|fn_name }}Future({%- call go::arg_list_decl(meth) -%}) {% call go::async_future_return_type(meth) %} {
+	_selfBuf := {{ ffi_converter_instance }}.Lower(_self)
+	{% call go::async_future_ffi_call_binding(meth, "_selfBuf", release_self) %}
+}
+{%- endif %}
 
 {%- endfor %}
 
@@ -44,7 +93,7 @@ func (c {{ rec|ffi_converter_name(ci) }}) Lift(rb RustBufferI) {{ type_name }} {
 	return LiftFromRustBuffer[{{ type_name }}](c, rb)
 }
 
-func (c {{ rec|ffi_converter_name(ci) }}) Read(reader io.Reader) {{ type_name }} {
+func (c {{ rec|ffi_converter_name(ci) }}) Read(reader *bytes.Reader) {{ type_name }} {
 	return {{ type_name }} {
 		{%- for field in rec.fields() %}
 			{{ field|read_fn(ci) }}(reader),
@@ -60,12 +109,20 @@ func (c {{ rec|ffi_converter_name(ci) }}) LowerExternal(value {{ type_name }}) E
 	return RustBufferFromC(LowerIntoRustBuffer[{{ type_name }}](c, value))
 }
 
-func (c {{ rec|ffi_converter_name(ci) }}) Write(writer io.Writer, value {{ type_name }}) {
+func (c {{ rec|ffi_converter_name(ci) }}) Write(writer *bytes.Buffer, value {{ type_name }}) {
 	{%- for field in rec.fields() %}
 		{{ field|write_fn(ci) }}(writer, value.{{ field.name()|field_name }});
 	{%- endfor %}
 }
 
+func (c {{ rec|ffi_converter_name(ci) }}) AllocationSize(value {{ type_name }}) uint64 {
+	size := uint64(0)
+	{%- for field in rec.fields() %}
+	size += {{ field|allocation_size_fn(ci) }}(value.{{ field.name()|field_name }})
+	{%- endfor %}
+	return size
+}
+
 type {{ ffi_destroyer_name }} struct {}
 
 func (_ {{ ffi_destroyer_name }}) Destroy(value {{ type_name }}) {
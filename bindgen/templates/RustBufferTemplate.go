@@ -78,7 +78,7 @@ func (cb GoRustBuffer) Free() {
 }
 
 func (cb GoRustBuffer) ToGoBytes() []byte {
-	return C.GoBytes(unsafe.Pointer(cb.inner.data), C.int(cb.inner.len))
+	return append([]byte{}, unsafe.Slice((*byte)(cb.inner.data), C.uint64_t(cb.inner.len))...)
 }
 
 
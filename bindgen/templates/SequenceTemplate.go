@@ -14,12 +14,17 @@ func (c {{ ffi_converter_name }}) Lift(rb RustBufferI) {{ type_name }} {
 	return LiftFromRustBuffer[{{ type_name }}](c, rb)
 }
 
-func (c {{ ffi_converter_name }}) Read(reader io.Reader) {{ type_name }} {
-	length := readInt32(reader)
+func (c {{ ffi_converter_name }}) Read(reader *bytes.Reader) {{ type_name }} {
+	length := readLength(reader)
 	if length == 0 {
 		return nil
 	}
-	result := make({{type_name}}, 0, length)
+	// Corrupt lengths fail once the buffer runs out, so only a bounded capacity is reserved
+	capacity := length
+	if capacity > 1024 {
+		capacity = 1024
+	}
+	result := make({{type_name}}, 0, capacity)
 	for i := int32(0); i < length; i++ {
 		result = append(result, {{ inner_type|read_fn(ci) }}(reader))
 	}
@@ -34,9 +39,9 @@ func (c {{ ffi_converter_name }}) LowerExternal(value {{ type_name }}) ExternalC
 	return RustBufferFromC(LowerIntoRustBuffer[{{ type_name }}](c, value))
 }
 
-func (c {{ ffi_converter_name }}) Write(writer io.Writer, value {{ type_name }}) {
+func (c {{ ffi_converter_name }}) Write(writer *bytes.Buffer, value {{ type_name }}) {
 	if len(value) > math.MaxInt32 {
-		panic("{{ type_name }} is too large to fit into Int32")
+		panic(&LowerError{Reason: "{{ type_name }} is too large to fit into Int32"})
 	}
 
 	writeInt32(writer, int32(len(value)))
@@ -45,6 +50,14 @@ func (c {{ ffi_converter_name }}) Write(writer io.Writer, value {{ type_name }})
 	}
 }
 
+func (c {{ ffi_converter_name }}) AllocationSize(value {{ type_name }}) uint64 {
+	size := uint64(4)
+	for _, item := range value {
+		size += {{ inner_type|allocation_size_fn(ci) }}(item)
+	}
+	return size
+}
+
 type {{ ffi_destroyer_name }} struct {}
 
 func ({{ ffi_destroyer_name }}) Destroy(sequence {{ type_name }}) {
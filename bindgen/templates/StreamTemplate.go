@@ -0,0 +1,56 @@
+{#/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */#}
+
+{{- self.add_import("iter") }}
+
+{%- for func in obj.methods() %}
+{%- if func.name() == stream.method() %}
+{%- if let Some(Type::Optional { inner_type }) = func.return_type() %}
+{%- let item_type = inner_type|type_name(ci) %}
+
+// All iterates over the items of the stream until it is exhausted or {{ func.name()|fn_name }}
+// fails, the error is then yielded as the last element. The {{ type_name }} is destroyed
+// once iteration ends, including when the loop is exited early.
+func (_self {{ impl_type_name }}) All(ctx context.Context) iter.Seq2[{{ item_type }}, error] {
+	return func(yield func({{ item_type }}, error) bool) {
+		defer _self.Destroy()
+
+		for {
+			item, err := _self.{{ func.name()|fn_name }}Ctx(ctx)
+			if err != nil {
+				var zero {{ item_type }}
+				yield(zero, err)
+				return
+			}
+			if item == nil || !yield(*item, nil) {
+				return
+			}
+		}
+	}
+}
+
+// Chan sends the items of the stream into the returned channel. The channel is closed once
+// the stream is exhausted, {{ func.name()|fn_name }} fails or ctx is done, use All to find out
+// about errors. The {{ type_name }} is destroyed when the channel is closed.
+func (_self {{ impl_type_name }}) Chan(ctx context.Context) <-chan {{ item_type }} {
+	items := make(chan {{ item_type }})
+	go func() {
+		defer close(items)
+
+		for item, err := range _self.All(ctx) {
+			if err != nil {
+				return
+			}
+			select {
+			case items <- item:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return items
+}
+{%- endif %}
+{%- endif %}
+{%- endfor %}
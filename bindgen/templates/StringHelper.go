@@ -3,6 +3,12 @@
  * file, You can obtain one at http://mozilla.org/MPL/2.0/. */#}
 
 {{- self.add_import("math") }}
+{%- if config.validate_utf8() %}
+{{- self.add_import("unicode/utf8") }}
+{%- else if config.replace_invalid_utf8() %}
+{{- self.add_import("strings") }}
+{{- self.add_import("unicode/utf8") }}
+{%- endif %}
 
 type {{ ffi_converter_name }} struct{}
 
@@ -10,48 +16,73 @@ var {{ ffi_converter_instance }} = {{ ffi_converter_name }}{}
 
 func ({{ ffi_converter_name }}) Lift(rb RustBufferI) string {
 	defer rb.Free()
-	reader := rb.AsReader()
-	b, err := io.ReadAll(reader)
-	if err != nil {
-		panic(fmt.Errorf("reading reader: %w", err))
-	}
-	return string(b)
+	// The conversion is the only copy out of the Rust buffer
+	return string(unsafe.Slice((*byte)(rb.Data()), rb.Len()))
 }
 
-func ({{ ffi_converter_name }}) Read(reader io.Reader) string {
-	length := readInt32(reader)
-	buffer := make([]byte, length)
-	read_length, err := reader.Read(buffer)
-	if err != nil && err != io.EOF {
-		panic(err)
-	}
-	if read_length != int(length) {
-		panic(fmt.Errorf("bad read length when reading string, expected %d, read %d", length, read_length))
-	}
+func ({{ ffi_converter_name }}) Read(reader *bytes.Reader) string {
+	buffer := readBytes(reader, readLength(reader))
 	return string(buffer)
 }
 
 func ({{ ffi_converter_name }}) Lower(value string) C.RustBuffer {
-	return stringToRustBuffer(value)
+	return stringToRustBuffer(uniffiLowerString(value))
 }
 
 func (c {{ ffi_converter_name }}) LowerExternal(value string) ExternalCRustBuffer {
-	return RustBufferFromC(stringToRustBuffer(value))
+	return RustBufferFromC(stringToRustBuffer(uniffiLowerString(value)))
 }
 
-func ({{ ffi_converter_name }}) Write(writer io.Writer, value string) {
+func ({{ ffi_converter_name }}) Write(writer *bytes.Buffer, value string) {
+	value = uniffiLowerString(value)
 	if len(value) > math.MaxInt32 {
-		panic("String is too large to fit into Int32")
+		panic(&LowerError{Reason: "string is too large to fit into Int32"})
 	}
 
 	writeInt32(writer, int32(len(value)))
-	write_length, err := io.WriteString(writer, value)
-	if err != nil {
-		panic(err)
+	writer.WriteString(value)
+}
+
+func ({{ ffi_converter_name }}) AllocationSize(value string) uint64 {
+	return 4 + uint64(uniffiLoweredStringLen(value))
+}
+
+// Applies the configured policy for strings that are not valid UTF-8, which Rust strings must be
+func uniffiLowerString(value string) string {
+	{%- if config.validate_utf8() %}
+	if !utf8.ValidString(value) {
+		panic(&LowerError{Reason: "string is not valid UTF-8"})
 	}
-	if write_length != len(value) {
-		panic(fmt.Errorf("bad write length when writing string, expected %d, written %d", len(value), write_length))
+	{%- else if config.replace_invalid_utf8() %}
+	value = strings.ToValidUTF8(value, "\uFFFD")
+	{%- endif %}
+	return value
+}
+
+// Length of the string once lowered, without allocating the replaced copy
+func uniffiLoweredStringLen(value string) int {
+	{%- if config.replace_invalid_utf8() %}
+	length := 0
+	// Consecutive invalid bytes are replaced by a single U+FFFD
+	invalid := false
+	for i := 0; i < len(value); {
+		r, width := utf8.DecodeRuneInString(value[i:])
+		if r == utf8.RuneError && width == 1 {
+			if !invalid {
+				length += utf8.RuneLen(utf8.RuneError)
+				invalid = true
+			}
+			i++
+			continue
+		}
+		invalid = false
+		length += width
+		i += width
 	}
+	return length
+	{%- else %}
+	return len(uniffiLowerString(value))
+	{%- endif %}
 }
 
 type {{ ffi_destroyer_name }} struct {}
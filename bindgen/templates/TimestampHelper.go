@@ -12,7 +12,7 @@ func (c FfiConverterTimestamp) Lift(rb RustBufferI) time.Time {
 	return LiftFromRustBuffer[time.Time](c, rb)
 }
 
-func (c FfiConverterTimestamp) Read(reader io.Reader) time.Time {
+func (c FfiConverterTimestamp) Read(reader *bytes.Reader) time.Time {
 	sec := readInt64(reader)
 	nsec := readUint32(reader)
 
@@ -32,10 +32,11 @@ func (c FfiConverterTimestamp) LowerExternal(value  time.Time) ExternalCRustBuff
 	return RustBufferFromC(c.Lower(value))
 }
 
-func (c FfiConverterTimestamp) Write(writer io.Writer, value time.Time) {
+func (c FfiConverterTimestamp) Write(writer *bytes.Buffer, value time.Time) {
 	sec := value.Unix()
 	nsec := uint32(value.Nanosecond())
-	if value.Unix() < 0 {
+	// Rust counts seconds and nanoseconds away from the epoch, while Unix() rounds down
+	if sec < 0 && nsec > 0 {
 		nsec = 1_000_000_000 - nsec
 		sec += 1
 	}
@@ -44,6 +45,11 @@ func (c FfiConverterTimestamp) Write(writer io.Writer, value time.Time) {
 	writeUint32(writer, nsec)
 }
 
+func (c FfiConverterTimestamp) AllocationSize(_ time.Time) uint64 {
+	// Seconds as u64 or i64, and nanoseconds as u32
+	return 12
+}
+
 type {{ ffi_destroyer_name }} struct {}
 
 func ({{ ffi_destroyer_name }}) Destroy(_ {{ type_name }}) {}
@@ -2,11 +2,26 @@
  * License, v. 2.0. If a copy of the MPL was not distributed with this
  * file, You can obtain one at http://mozilla.org/MPL/2.0/. */#}
 
+{%- let fn_errors = config.recovered_errors() %}
 {%- call go::docstring(func, 0) %}
-func {{ func.name()|fn_name}}({%- call go::arg_list_decl(func) -%}) {% call go::return_type_decl(func) %} {
+func {{ func.name()|fn_name}}({%- call go::arg_list_decl(func) -%}) {% call go::fn_return_type_decl(func, fn_errors) %} {
+	{%- call go::recover_rust_panic(func, fn_errors) %}
 {%- if func.is_async() %}
-	{% call go::async_ffi_call_binding(func, "") %}
+	{% call go::async_ffi_call_binding(func, "", fn_errors) %}
 {%- else %}
-	{% call go::ffi_call_binding(func, "") %}
+	{% call go::ffi_call_binding(func, "", fn_errors) %}
 {%- endif %}
 }
+{%- if func.is_async() %}
+{% call go::async_ctx_docstring(func.name()|fn_name) %}
+func {{ func.name()|fn_name}}Ctx({%- call go::async_ctx_arg_list_decl(func) -%}) {% call go::fn_return_type_decl(func, true) %} {
+	{%- call go::recover_rust_panic(func, true) %}
+	{% call go::async_ctx_ffi_call_binding(func, "") %}
+}
+{% call go::async_future_docstring(func.name()|fn_name) %}
+func {{ func.name()|fn_name}}Future({%- call go::arg_list_decl(func) -%}) {% call go::async_future_return_type(func) %} {
+	{% call go::async_future_ffi_call_binding(func, "") %}
+}
+{%- endif %}
+{%- let go_name = func.name()|fn_name %}
+{%- call go::with_options(func, go_name, go_name, fn_errors) %}
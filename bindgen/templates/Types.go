@@ -89,6 +89,17 @@
 
 {%- else %}
 {%- endmatch %}
+
+{%- if type_|writes_handles(ci) %}
+
+// Values of this type hold object handles, which are released again when lowering fails halfway
+func ({{ ffi_converter_name }}) writesHandles() {}
+{%- endif %}
+{%- if type_|writes_custom_types(ci) %}
+
+// Values of this type are written without computing their size first, see LowerIntoRustBuffer
+func ({{ ffi_converter_name }}) writesCustomTypes() {}
+{%- endif %}
 {%- endfor %}
 
 {%- for type_ in ci.iter_external_types() %}
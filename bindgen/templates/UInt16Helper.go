@@ -10,15 +10,19 @@ func ({{ ffi_converter_name }}) Lower(value uint16) C.uint16_t {
 	return C.uint16_t(value)
 }
 
-func ({{ ffi_converter_name }}) Write(writer io.Writer, value uint16) {
+func ({{ ffi_converter_name }}) Write(writer *bytes.Buffer, value uint16) {
 	writeUint16(writer, value)
 }
 
+func ({{ ffi_converter_name }}) AllocationSize(_ uint16) uint64 {
+	return 2
+}
+
 func ({{ ffi_converter_name }}) Lift(value C.uint16_t) uint16 {
 	return uint16(value)
 }
 
-func ({{ ffi_converter_name }}) Read(reader io.Reader) uint16 {
+func ({{ ffi_converter_name }}) Read(reader *bytes.Reader) uint16 {
 	return readUint16(reader)
 }
 
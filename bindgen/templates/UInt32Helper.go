@@ -10,15 +10,19 @@ func ({{ ffi_converter_name }}) Lower(value uint32) C.uint32_t {
 	return C.uint32_t(value)
 }
 
-func ({{ ffi_converter_name }}) Write(writer io.Writer, value uint32) {
+func ({{ ffi_converter_name }}) Write(writer *bytes.Buffer, value uint32) {
 	writeUint32(writer, value)
 }
 
+func ({{ ffi_converter_name }}) AllocationSize(_ uint32) uint64 {
+	return 4
+}
+
 func ({{ ffi_converter_name }}) Lift(value C.uint32_t) uint32 {
 	return uint32(value)
 }
 
-func ({{ ffi_converter_name }}) Read(reader io.Reader) uint32 {
+func ({{ ffi_converter_name }}) Read(reader *bytes.Reader) uint32 {
 	return readUint32(reader)
 }
 
@@ -10,15 +10,19 @@ func ({{ ffi_converter_name }}) Lower(value uint64) C.uint64_t {
 	return C.uint64_t(value)
 }
 
-func ({{ ffi_converter_name }}) Write(writer io.Writer, value uint64) {
+func ({{ ffi_converter_name }}) Write(writer *bytes.Buffer, value uint64) {
 	writeUint64(writer, value)
 }
 
+func ({{ ffi_converter_name }}) AllocationSize(_ uint64) uint64 {
+	return 8
+}
+
 func ({{ ffi_converter_name }}) Lift(value C.uint64_t) uint64 {
 	return uint64(value)
 }
 
-func ({{ ffi_converter_name }}) Read(reader io.Reader) uint64 {
+func ({{ ffi_converter_name }}) Read(reader *bytes.Reader) uint64 {
 	return readUint64(reader)
 }
 
@@ -10,15 +10,19 @@ func ({{ ffi_converter_name }}) Lower(value uint8) C.uint8_t {
 	return C.uint8_t(value)
 }
 
-func ({{ ffi_converter_name }}) Write(writer io.Writer, value uint8) {
+func ({{ ffi_converter_name }}) Write(writer *bytes.Buffer, value uint8) {
 	writeUint8(writer, value)
 }
 
+func ({{ ffi_converter_name }}) AllocationSize(_ uint8) uint64 {
+	return 1
+}
+
 func ({{ ffi_converter_name }}) Lift(value C.uint8_t) uint8 {
 	return uint8(value)
 }
 
-func ({{ ffi_converter_name }}) Read(reader io.Reader) uint8 {
+func ({{ ffi_converter_name }}) Read(reader *bytes.Reader) uint8 {
 	return readUint8(reader)
 }
 
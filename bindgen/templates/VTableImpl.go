@@ -14,6 +14,9 @@ func {{ callback_name }}(
 	    callStatus *C.RustCallStatus,
 	    {%- endif -%}	
 	) {
+	{%- if !meth.is_async() && ffi_callback.has_rust_call_status_arg() %}
+	defer uniffiRecoverCallbackPanic(callStatus)
+	{%- endif %}
 	handle := uint64(uniffiHandle)
 	uniffiObj, ok := {{ ffi_converter_instance }}.handleMap.tryGet(handle)
 	if !ok {
@@ -22,26 +25,16 @@ func {{ callback_name }}(
 	
 	{% if meth.is_async() %}
 	{%- let result_struct = meth.foreign_future_ffi_result_struct().name()|ffi_struct_name %}
-	result := make(chan C.{{ result_struct }}, 1)
-	cancel := make(chan struct{}, 1)
+	// Cancelled once Rust drops the future or the result has been delivered
+	ctx, cancel := context.WithCancel(context.Background())
 	guardHandle := cgo.NewHandle(cancel)
 	*uniffiOutDroppedCallback = C.UniffiForeignFutureDroppedCallbackStruct {
 		handle: C.uint64_t(guardHandle),
 		free: C.UniffiForeignFutureDroppedCallback(C.{{ config|free_gorutine_callback }}),
 	}
-	
-	// Wait for compleation or cancel
-	go func() {
-		select {
-			case <-cancel:
-			case res := <-result:
-				{{ ffi_callback|find_ffi_callback_helper -}}
-					(uniffiFutureCallback, uniffiCallbackData, res)
-		}
-	}()
 
 	// Eval callback asynchroniously
-	go func() {
+	task := func() {
         asyncResult := &C.{{ result_struct }}{};
     	{%- if meth.return_type().is_some() %}
     	uniffiOutReturn := &asyncResult.returnValue
@@ -50,12 +43,21 @@ func {{ callback_name }}(
     	callStatus := &asyncResult.callStatus
     	{%- endif %}
     	defer func() {
-    		result <- *asyncResult
+    		// Nobody is waiting for the result once the future is dropped
+    		if ctx.Err() == nil {
+    			{{ ffi_callback|find_ffi_callback_helper -}}
+    				(uniffiFutureCallback, uniffiCallbackData, *asyncResult)
+    		}
+    		cancel()
     	}()
+    	defer uniffiRecoverCallbackPanic(&asyncResult.callStatus)
 	{% endif %}
 
 	{% call go::func_return_vars(meth, suffix = ":=") %}
     uniffiObj.{{ meth.name()|fn_name }}(
+        {%- if takes_ctx && meth.is_async() %}
+        ctx,
+        {%- endif %}
         {%- for arg in meth.arguments() %}
         {%- let var = arg.name()|var_name %}
         {{ arg|lift_fn(ci) }}({% call go::remap_ffi_val(arg.as_type(), var) %}),
@@ -72,9 +74,7 @@ func {{ callback_name }}(
 				errorBuf: {{ error_type|lower_fn(ci) }}(actualError),
 			}
 		} else {
-			*callStatus = C.RustCallStatus {
-				code: C.int8_t(uniffiCallbackUnexpectedResultError),
-			}
+			*callStatus = uniffiUnexpectedCallbackStatus(err.Error())
 		}
 		return
 	}
@@ -86,7 +86,14 @@ func {{ callback_name }}(
 	{%- endif %}
 
 	{%- if meth.is_async() %}
-	}()
+	}
+
+	if err := currentCallbackExecutor().Execute(task); err != nil {
+		{{ ffi_callback|find_ffi_callback_helper -}}
+			(uniffiFutureCallback, uniffiCallbackData, C.{{ result_struct }} {
+				callStatus: uniffiUnexpectedCallbackStatus(err.Error()),
+			})
+	}
 	{%- endif %}
 }
 
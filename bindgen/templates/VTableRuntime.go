@@ -1,4 +1,5 @@
 {% if self.include_once_check("CallbackHelpers.go") %}{% include "CallbackHelpers.go" %}{% endif %}
+{% if self.include_once_check("CallbackExecutor.go") %}{% include "CallbackExecutor.go" %}{% endif %}
 
 type concurrentHandleMap[T any] struct {
 	handles       map[uint64]T
@@ -15,9 +15,9 @@
 	{%- when Some with (return_type) -%}
 		{%- match func.throws_type() -%}
 		{%- when Some with (throws_type) -%}
-		({{ return_type|type_name(ci) }}, error)
+		({% call result_type(func, return_type) %}, error)
 		{%- when None -%}
-		{{ return_type|type_name(ci) }}
+		{% call result_type(func, return_type) %}
 		{%- endmatch %}
 	{%- when None -%}
 		{%- match func.throws_type() -%}
@@ -28,20 +28,43 @@
 	{%- endmatch %}
 {%- endmacro %}
 
-{% macro ffi_call_binding(func, prefix) %}	
+// Go type of a function result, a borrowed view for the functions listed in `borrowed_results`
+{%- macro result_type(func, return_type) -%}
+	{%- match config.borrowed_result(ci, func.ffi_func().name()) -%}
+	{%- when Some with (kind) -%}
+	*Borrowed{{ kind }}
+	{%- when None -%}
+	{{ return_type|type_name(ci) }}
+	{%- endmatch -%}
+{%- endmacro -%}
+
+{%- macro result_lift_fn(func, return_type) -%}
+	{%- match config.borrowed_result(ci, func.ffi_func().name()) -%}
+	{%- when Some with (kind) -%}
+	uniffiBorrow{{ kind }}
+	{%- when None -%}
+	{{ return_type|lift_fn(ci) }}
+	{%- endmatch -%}
+{%- endmacro %}
+
+// Object methods returning errors on use-after-destroy also return nil from non-throwing calls
+{% macro ffi_call_binding(func, prefix, nil_err = false, release = "") %}	
+	{%- call ensure_initialized(func, nil_err, false, prefix, release) %}
+	{%- call lower_args(func, prefix, nil_err, false, release) %}
 	{%- match func.return_type() -%}
 	{%- when Some with (return_type) -%}
 		{%- match func.throws_type() -%}
 		{%- when Some with (throws_type) -%}
 		_uniffiRV, _uniffiErr := {% call to_ffi_call(func, prefix) %}
 		if _uniffiErr != nil {
-			var _uniffiDefaultValue {{ return_type|type_name(ci) }}
+			var _uniffiDefaultValue {% call result_type(func, return_type) %}
 			return _uniffiDefaultValue, _uniffiErr
 		} else {
-			return {{ return_type|lift_fn(ci) }}(_uniffiRV), nil
+			return {% call result_lift_fn(func, return_type) %}(_uniffiRV), nil
 		}
 		{%- when None -%}
-		return {{ return_type|lift_fn(ci) }}({% call to_ffi_call(func, prefix) %})
+		return {% call result_lift_fn(func, return_type) %}({% call to_ffi_call(func, prefix) %})
+		{%- if nil_err %}, nil{% endif %}
 		{%- endmatch -%}
 	{%- when None -%}
 		{%- match func.throws_type() -%}
@@ -50,6 +73,9 @@
 		return _uniffiErr.AsError()
 		{%- when None -%}
 		{% call to_ffi_call(func, prefix) %}
+		{%- if nil_err %}
+		return nil
+		{%- endif %}
 		{%- endmatch -%}
 	{%- endmatch -%}
 {% endmacro %}
@@ -100,7 +126,11 @@
 		{{ prefix }},
 	{%- endif %}
 	{%- for arg in func.arguments() %}
+		{%- if arg|lower_can_fail(ci) -%}
+		_uniffiArg{{ loop.index0 }}
+		{%- else -%}
 		{%- call lower_fn_call(arg) -%}
+		{%- endif -%}
 		{%- if !loop.last %}, {% endif %}
 	{%- endfor %}
 	{%- if func.ffi_func().has_rust_call_status_arg() -%}
@@ -152,12 +182,99 @@
     {%- endmatch -%}
 {%- endmacro -%}
 
-{%- macro async_ffi_call_binding(func, prefix) -%}
+{%- macro async_ffi_call_binding(func, prefix, nil_err = false, release = "") -%}
+	{%- call ensure_initialized(func, nil_err, false, prefix, release) %}
+	{%- call lower_args(func, prefix, nil_err, false, release) %}
 	{%- call func_return_vars_pairs(func, suffix = ":=") -%}
-	
+	uniffiRustCallAsync[{% call async_error_type(func) %}](
+		{%- call async_future_fns(func, prefix) %}
+		// freeFn
+		func (handle C.uint64_t) {
+			C.{{ func.ffi_rust_future_free(ci) }}(handle)
+		},
+	)
+
+	{% call func_nil_err_check(func) %}
+
+	{%- if nil_err && func.throws_type().is_none() %}
+	{%- match func.return_type() %}
+	{%- when Some with (_) %}
+	return res, nil
+	{%- when None %}
+	return nil
+	{%- endmatch %}
+	{%- else %}
+	{% call func_return_vars(func, prefix = "return") %}
+	{%- endif %}
+{%- endmacro -%}
+
+// A ctx that is already done fails the call before anything is lowered or sent to Rust
+{%- macro async_ctx_ffi_call_binding(func, prefix, release = "") -%}
+	if ctx.Err() != nil {
+		_uniffiCtxErr := fmt.Errorf("rust future cancelled: %w", ctx.Err())
+		{%- call release_receiver(prefix, release) %}
+		{%- call return_err_value(func, "_uniffiCtxErr") %}
+	}
+	{%- call ensure_initialized(func, true, false, prefix, release) %}
+	{%- call lower_args(func, prefix, true, false, release) %}
+	{%- call async_ctx_return_vars(func) %} := uniffiRustCallAsyncCtx[{% call async_error_type(func) %}](
+		ctx,
+		{%- call async_future_fns(func, prefix) %}
+		// cancelFn
+		func (handle C.uint64_t) {
+			C.{{ func.ffi_rust_future_cancel(ci) }}(handle)
+		},
+		// freeFn
+		func (handle C.uint64_t) {
+			C.{{ func.ffi_rust_future_free(ci) }}(handle)
+		},
+	)
+
+	{% call async_ctx_return(func) %}
+{%- endmacro -%}
+
+{%- macro async_future_ffi_call_binding(func, prefix, release = "") -%}
+	{%- call ensure_initialized(func, false, true, prefix, release) %}
+	{%- call lower_args(func, prefix, false, true, release) %}
+	return newFuture[{% call async_error_type(func) %}](
+		{%- call async_future_fns(func, prefix) %}
+		// cancelFn
+		func (handle C.uint64_t) {
+			C.{{ func.ffi_rust_future_cancel(ci) }}(handle)
+		},
+		// freeFn
+		func (handle C.uint64_t) {
+			C.{{ func.ffi_rust_future_free(ci) }}(handle)
+		},
+	)
+{%- endmacro -%}
+
+{%- macro async_future_return_type(func) -%}
+	*Future[{% call async_future_value_type(func) %}]
+{%- endmacro -%}
+
+{%- macro async_future_value_type(func) -%}
+	{%- match func.return_type() -%}
+	{%- when Some with (return_type) -%}
+	{{ return_type|type_name(ci) }}
+	{%- when None -%}
+	struct{}
+	{%- endmatch -%}
+{%- endmacro -%}
+
+{%- macro async_error_type(func) -%}
+	{%- match func.throws_type() -%}
+	{%- when Some with (e) -%}
+	{{ e|type_name(ci) }}
+	{%- when None -%}
+	error
+	{%- endmatch -%}
+{%- endmacro -%}
+
+// Error converter, completeFn, liftFn, the future itself and pollFn, shared by all async calls
+{%- macro async_future_fns(func, prefix) -%}
     {%- match (func.return_type(), func.throws_type()) %}
-    {%- when (Some(return_type), Some(e)) -%}
-	uniffiRustCallAsync[{{ e|type_name(ci) }}](
+    {%- when (Some(return_type), Some(e)) %}
         {{ e|ffi_converter_instance(ci) }},
 		// completeFn
 		func(handle C.uint64_t, status *C.RustCallStatus) {{ return_type|ffi_type_name }} {
@@ -168,8 +285,7 @@
 		func(ffi {{ return_type|ffi_type_name }}) {{ return_type|type_name(ci) }} {
 			return {{ return_type|lift_fn(ci) }}(ffi)
 		},
-    {%- when (None, Some(e)) -%}
-	uniffiRustCallAsync[{{ e|type_name(ci) }}](
+    {%- when (None, Some(e)) %}
         {{ e|ffi_converter_instance(ci) }},
 		// completeFn
 		func(handle C.uint64_t, status *C.RustCallStatus) struct{} {
@@ -178,8 +294,7 @@
 		},
 		// liftFn
 		func(_ struct{}) struct{} { return struct{}{} },
-    {%- when (Some(return_type), None) -%}
-	uniffiRustCallAsync[error](
+    {%- when (Some(return_type), None) %}
         nil,
 		// completeFn
 		func(handle C.uint64_t, status *C.RustCallStatus) {{ return_type|ffi_type_name }} {
@@ -190,8 +305,7 @@
 		func(ffi {{ return_type|ffi_type_name }}) {{ return_type|type_name(ci) }} {
 			return {{ return_type|lift_fn(ci) }}(ffi)
 		},
-    {%- when (None, None) -%}
-	uniffiRustCallAsync[error](
+    {%- when (None, None) %}
         nil,
 		// completeFn
 		func(handle C.uint64_t, status *C.RustCallStatus) struct{} {
@@ -206,15 +320,236 @@
 		func (handle C.uint64_t, continuation C.UniffiRustFutureContinuationCallback, data C.uint64_t) {
 			C.{{ func.ffi_rust_future_poll(ci) }}(handle, continuation, data)
 		},
-		// freeFn
-		func (handle C.uint64_t) {
-			C.{{ func.ffi_rust_future_free(ci) }}(handle)
-		},
+{%- endmacro -%}
+
+{% macro async_ctx_arg_list_decl(func) -%}
+	ctx context.Context
+	{%- for arg in func.arguments() -%}
+	, {{ arg.name()|var_name }} {{ arg|type_name(ci) }}
+	{%- endfor -%}
+{%- endmacro %}
+
+// Arglist of a callback or trait interface method, async methods take a leading ctx when configured
+{% macro interface_arg_list_decl(func, takes_ctx) %}
+	{%- if takes_ctx && func.is_async() -%}
+	{% call async_ctx_arg_list_decl(func) %}
+	{%- else -%}
+	{% call arg_list_decl(func) %}
+	{%- endif -%}
+{%- endmacro %}
+
+{% macro async_ctx_return_type_decl(func) %}
+	{%- match func.return_type() -%}
+	{%- when Some with (return_type) -%}
+	({% call result_type(func, return_type) %}, error)
+	{%- when None -%}
+	error
+	{%- endmatch %}
+{%- endmacro %}
+
+// Object methods also return an error when use-after-destroy is reported as an error
+{% macro method_return_type_decl(func, with_error) %}
+	{%- if with_error -%}
+	{% call async_ctx_return_type_decl(func) %}
+	{%- else -%}
+	{% call return_type_decl(func) %}
+	{%- endif -%}
+{%- endmacro %}
+
+// Functions reporting Rust panics as errors name their error result, so that the deferred
+// recover in recover_rust_panic can set it
+{% macro fn_return_type_decl(func, with_error) %}
+	{%- if with_error && config.recovered_errors() -%}
+		{%- match func.return_type() -%}
+		{%- when Some with (return_type) -%}
+		(_ {% call result_type(func, return_type) %}, _uniffiPanicErr error)
+		{%- when None -%}
+		(_uniffiPanicErr error)
+		{%- endmatch -%}
+	{%- else -%}
+	{% call method_return_type_decl(func, with_error) %}
+	{%- endif -%}
+{%- endmacro %}
+
+{%- macro recover_rust_panic(func, with_error) %}
+	{%- if with_error && config.recovered_errors() %}
+	defer uniffiRecoverRustPanic("{{ func.ffi_func().name() }}", &_uniffiPanicErr)
+	{%- endif %}
+{%- endmacro %}
+
+// Holds the object's call counter for the rest of the method. With with_error, a destroyed
+// object makes the method return the error instead of panicking.
+{%- macro acquire_object_pointer(func, type_name, with_error) %}
+	{%- if with_error %}
+	_pointer, _uniffiPointerErr := _self.ffiObject.tryIncrementPointer("{{ type_name }}")
+	if _uniffiPointerErr != nil {
+		{%- match func.return_type() %}
+		{%- when Some with (return_type) %}
+		var _uniffiDefaultValue {% call result_type(func, return_type) %}
+		return _uniffiDefaultValue, _uniffiPointerErr
+		{%- when None %}
+		return _uniffiPointerErr
+		{%- endmatch %}
+	}
+	{%- else %}
+	_pointer := _self.ffiObject.incrementPointer("{{ type_name }}")
+	{%- endif %}
+	defer _self.ffiObject.decrementPointer()
+{%- endmacro %}
+
+{%- macro async_ctx_return_vars(func) -%}
+    {%- match (func.return_type(), func.throws_type()) -%}
+    {%- when (Some(_), Some(_)) -%} res, err, ctxErr
+    {%- when (None, Some(_)) -%} _, err, ctxErr
+    {%- when (Some(_), None) -%} res, _, ctxErr
+    {%- when (None, None) -%} _, _, ctxErr
+    {%- endmatch -%}
+{%- endmacro -%}
+
+{%- macro async_ctx_return(func) -%}
+    {%- match (func.return_type(), func.throws_type()) -%}
+    {%- when (Some(_), Some(_)) -%}
+	if ctxErr != nil {
+		return res, ctxErr
+	}
+	if err == nil {
+		return res, nil
+	}
+	return res, err
+    {%- when (None, Some(_)) -%}
+	if ctxErr != nil {
+		return ctxErr
+	}
+	if err == nil {
+		return nil
+	}
+	return err
+    {%- when (Some(_), None) -%}
+	return res, ctxErr
+    {%- when (None, None) -%}
+	return ctxErr
+    {%- endmatch -%}
+{%- endmacro -%}
+
+// Interface methods taking a ctx forward to their Ctx twin. Without an error in the
+// signature there is nowhere to report cancellation, so the zero value is returned.
+// Methods without an error result only give up silently when ctx is done, any other error,
+// e.g. a *LowerError, is raised as a panic like in the methods not taking a ctx
+{%- macro async_ctx_delegate(func) %}
+	{%- match (func.return_type(), func.throws_type()) %}
+	{%- when (Some(_), Some(_)) %}
+	return _self.{{ func.name()|fn_name }}Ctx(ctx
+	{%- when (None, Some(_)) %}
+	return _self.{{ func.name()|fn_name }}Ctx(ctx
+	{%- when (Some(_), None) %}
+	res, err := _self.{{ func.name()|fn_name }}Ctx(ctx
+	{%- when (None, None) %}
+	err := _self.{{ func.name()|fn_name }}Ctx(ctx
+	{%- endmatch %}
+	{%- for arg in func.arguments() -%}
+	, {{ arg.name()|var_name }}
+	{%- endfor -%}
 	)
+	{%- if func.throws_type().is_none() %}
+	if err != nil && (ctx.Err() == nil || !errors.Is(err, ctx.Err())) {
+		panic(err)
+	}
+	{%- if func.return_type().is_some() %}
+	return res
+	{%- endif %}
+	{%- endif %}
+{%- endmacro %}
 
-	{% call func_nil_err_check(func) %}
+{%- macro async_future_docstring(name) %}
+// {{ name }}Future starts {{ name }} without waiting for it, the result is collected
+// through the returned Future.
+{%- endmacro %}
 
-	{% call func_return_vars(func, prefix = "return") %}
+{%- macro async_ctx_docstring(name) %}
+// {{ name }}Ctx is the same as {{ name }}, but gives up once ctx is done. In that case
+// the underlying Rust future is cancelled and the wrapped ctx.Err() is returned.
+{%- endmacro %}
+
+// Arguments whose lowering can fail are lowered into locals before the FFI call. On failure
+// the receiver and the arguments lowered so far are released, including the object handles
+// they hold, and the *LowerError is returned, or raised as a panic when the function has no
+// error result.
+{%- macro lower_args(func, prefix, return_err, future = false, release = "") %}
+	{%- for arg in func.arguments() %}
+	{%- if arg|lower_can_fail(ci) %}
+	{%- let lowered = loop.index0 %}
+	_uniffiArg{{ lowered }}, _uniffiLowerErr := uniffiLowerArg("{{ arg.name()|var_name }}", {{ arg.name()|var_name }}, {% call lower_arg_fn(arg) %})
+	if _uniffiLowerErr != nil {
+		{%- call release_receiver(prefix, release) %}
+		{%- for previous in func.arguments() %}
+		{%- if loop.index0 < lowered && previous|lower_can_fail(ci) %}
+		{{ previous|release_lowered_arg(loop.index0, ci) }}
+		{%- endif %}
+		{%- endfor %}
+		{%- if future %}
+		return newFailedFuture[{% call async_future_value_type(func) %}](_uniffiLowerErr)
+		{%- else if func.throws_type().is_some() %}
+		{%- call return_err_value(func, "_uniffiLowerErr") %}
+		{%- else if return_err %}
+		{%- call return_err_value(func, "_uniffiLowerErr") %}
+		{%- else %}
+		panic(_uniffiLowerErr)
+		{%- endif %}
+	}
+	{%- endif %}
+	{%- endfor %}
+{%- endmacro %}
+
+// With `deferred_initialization`, entry points initialize the library on first use. A failure is
+// returned like lowering failures are, or raised as a panic when the function has no error
+// result. The receiver lowered before is released first.
+{%- macro ensure_initialized(func, return_err, future = false, prefix = "", release = "") %}
+	{%- if config.deferred_initialization() %}
+	if _uniffiInitErr := Initialize(); _uniffiInitErr != nil {
+		{%- call release_receiver(prefix, release) %}
+		{%- if future %}
+		return newFailedFuture[{% call async_future_value_type(func) %}](_uniffiInitErr)
+		{%- else if func.throws_type().is_some() %}
+		{%- call return_err_value(func, "_uniffiInitErr") %}
+		{%- else if return_err %}
+		{%- call return_err_value(func, "_uniffiInitErr") %}
+		{%- else %}
+		panic(_uniffiInitErr)
+		{%- endif %}
+	}
+	{%- endif %}
+{%- endmacro %}
+
+{%- macro return_err_value(func, err) %}
+	{%- match func.return_type() %}
+	{%- when Some with (return_type) %}
+		var _uniffiDefaultValue {% call result_type(func, return_type) %}
+		return _uniffiDefaultValue, {{ err }}
+	{%- when None %}
+		return {{ err }}
+	{%- endmatch %}
+{%- endmacro %}
+
+// The receiver of a method is lowered before its arguments. Objects pass a handle cloned by
+// acquire_object_pointer, records and enums give the statement releasing their buffer.
+{%- macro release_receiver(prefix, release) %}
+	{%- if !release.is_empty() %}
+		{{ release }}
+	{%- else if prefix == "_pointer" %}
+		_self.ffiObject.freeHandle(_pointer)
+	{%- else if prefix.starts_with("_selfBuf") %}
+		uniffiFreeLowered(_selfBuf)
+	{%- endif %}
+{%- endmacro %}
+
+// Lowers an argument into the value passed to the FFI function, external types that need it
+// are lowered into the RustBuffer of this package
+{%- macro lower_arg_fn(arg) -%}
+{%- if arg|requires_lower_external(ci) -%}
+func(value {{ arg|type_name(ci) }}) C.RustBuffer { return CFromRustBuffer({{ arg|lower_external_fn(ci) }}(value)) }
+{%- else -%}
+{{ arg|lower_fn(ci) }}
+{%- endif -%}
 {%- endmacro -%}
 
 {%- macro lower_fn_call(arg) -%}
@@ -225,6 +560,88 @@ CFromRustBuffer({{ arg|lower_external_fn(ci) }}({{ arg.name()|var_name }}))
 {%- endif %}
 {%- endmacro -%}
 
+// Attribute logging a single record or variant field, redacted when configured as sensitive
+{%- macro log_attr(field, key, receiver, go_field, sensitive) %}
+	{%- if sensitive %}
+		slog.String("{{ key }}", uniffiRedacted),
+	{%- else %}
+	{%- if let Type::Optional { inner_type } = field.as_type() %}
+		uniffiLogOptional[{{ inner_type|type_name(ci) }}]("{{ key }}", {{ receiver }}.{{ go_field }}),
+	{%- else %}
+		slog.Any("{{ key }}", {{ receiver }}.{{ go_field }}),
+	{%- endif %}
+	{%- endif %}
+{%- endmacro %}
+
+// Functional options for the trailing arguments of func that have a default in Rust. The
+// <prefix>With<Argument> options configure <name>WithOptions, a method of receiver if given,
+// which delegates to <name>.
+{%- macro with_options(func, prefix, name, with_error, receiver = "", takes_ctx = false) %}
+{%- let defaults_start = func|default_args_start(ci) %}
+{%- if defaults_start < func.arguments().len() %}
+{%- let options_name = prefix|var_name %}
+{%- let pass_ctx = takes_ctx && func.is_async() %}
+
+// {{ prefix }}Option sets an argument of {{ name }}WithOptions that has a default in Rust.
+type {{ prefix }}Option func(*{{ options_name }}Options)
+
+type {{ options_name }}Options struct {
+	{%- for arg in func.arguments() %}
+	{%- if loop.index0 >= defaults_start %}
+	{{ arg.name()|var_name }} {{ arg|type_name(ci) }}
+	{%- endif %}
+	{%- endfor %}
+}
+{%- for arg in func.arguments() %}
+{%- if loop.index0 >= defaults_start %}
+
+// {{ prefix }}With{{ arg.name()|class_name }} sets the {{ arg.name() }} argument of {{ name }}WithOptions.
+func {{ prefix }}With{{ arg.name()|class_name }}({{ arg.name()|var_name }} {{ arg|type_name(ci) }}) {{ prefix }}Option {
+	return func(_uniffiOptions *{{ options_name }}Options) {
+		_uniffiOptions.{{ arg.name()|var_name }} = {{ arg.name()|var_name }}
+	}
+}
+{%- endif %}
+{%- endfor %}
+
+// {{ name }}WithOptions calls {{ name }}, with the arguments not set by opts falling back to
+// their defaults in Rust.
+func {% if !receiver.is_empty() %}(_self {{ receiver }}) {% endif %}{{ name }}WithOptions(
+	{%- if pass_ctx %}ctx context.Context, {% endif %}
+	{%- for arg in func.arguments() %}
+	{%- if loop.index0 < defaults_start -%}
+	{{ arg.name()|var_name }} {{ arg|type_name(ci) }},{{ " " }}
+	{%- endif %}
+	{%- endfor -%}
+	opts ...{{ prefix }}Option) {% call method_return_type_decl(func, with_error) %} {
+	_uniffiOptions := {{ options_name }}Options{
+		{%- for arg in func.arguments() %}
+		{%- if loop.index0 >= defaults_start %}
+		{%- if let Some(value) = arg.default_value() %}
+		{{ arg.name()|var_name }}: {{ value|default_value(arg, ci) }},
+		{%- endif %}
+		{%- endif %}
+		{%- endfor %}
+	}
+	for _, opt := range opts {
+		opt(&_uniffiOptions)
+	}
+	{% if func.return_type().is_some() || func.throws_type().is_some() || with_error %}return {% endif -%}
+	{% if !receiver.is_empty() %}_self.{% endif %}{{ name }}(
+	{%- if pass_ctx %}ctx, {% endif %}
+	{%- for arg in func.arguments() -%}
+	{%- if loop.index0 < defaults_start -%}
+	{{ arg.name()|var_name }}
+	{%- else -%}
+	_uniffiOptions.{{ arg.name()|var_name }}
+	{%- endif -%}
+	{%- if !loop.last %}, {% endif -%}
+	{%- endfor -%}
+	)
+}
+{%- endif %}
+{%- endmacro %}
+
 {%- macro docstring(defn, indent_tabs) %}
 {%- match defn.docstring() %}
 {%- when Some(docstring) %}
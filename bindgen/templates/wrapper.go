@@ -17,10 +17,6 @@ import (
 	"io"
 	"unsafe"
 	"encoding/binary"
-	{%- if ci.has_async_fns() %}
-	"reflect"
-	"runtime/cgo"
-	{%- endif %}
 	{%- for imported_package in self.imports() %}
 	{{ imported_package.render() }}
 	{%- endfor %}
@@ -0,0 +1,49 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package binding_tests
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/NordSecurity/uniffi-bindgen-go/binding_tests/generated/serialization"
+	"github.com/stretchr/testify/assert"
+)
+
+// The serialization fixture lists `repeat_bytes` and `Payload.text` in `borrowed_results`
+
+func TestBorrowedBytes(t *testing.T) {
+	borrowed := serialization.RepeatBytes([]byte{1, 2, 3}, 1_000_000)
+	assert.Equal(t, bytes.Repeat([]byte{1, 2, 3}, 1_000_000), borrowed.Bytes())
+
+	owned := borrowed.Clone()
+	borrowed.Release()
+	borrowed.Release()
+	assert.Len(t, owned, 3_000_000)
+	assert.Panics(t, func() { borrowed.Bytes() })
+}
+
+func TestBorrowedEmptyBytes(t *testing.T) {
+	borrowed := serialization.RepeatBytes([]byte{1}, 0)
+	defer borrowed.Release()
+	assert.Empty(t, borrowed.Bytes())
+}
+
+func TestBorrowedString(t *testing.T) {
+	payload := serialization.NewPayload("borrowed ✓")
+	defer payload.Destroy()
+
+	borrowed := payload.Text()
+	assert.Equal(t, "borrowed ✓", borrowed.String())
+	owned := borrowed.Clone()
+	borrowed.Release()
+	assert.Equal(t, "borrowed ✓", owned)
+	assert.Panics(t, func() { _ = borrowed.String() })
+}
+
+func TestOwnedStringIsCopied(t *testing.T) {
+	assert.Equal(t, strings.Repeat("ab", 1_000_000), serialization.RepeatString("ab", 1_000_000))
+}
@@ -5,6 +5,8 @@
 package binding_tests
 
 import (
+	"bytes"
+	"errors"
 	"math"
 	"testing"
 	"time"
@@ -45,6 +47,28 @@ func TestDurationMax(t *testing.T) {
 	}
 }
 
+func TestNegativeDurationReturnsLowerError(t *testing.T) {
+	_, err := chronological.Add(time.Unix(100, 0), -time.Second)
+	var lowerErr *chronological.LowerError
+	if assert.True(t, errors.As(err, &lowerErr)) {
+		assert.Equal(t, "b", lowerErr.Argument)
+		assert.Contains(t, lowerErr.Reason, "negative duration")
+	}
+}
+
+func TestNegativeDurationPanicsWithLowerError(t *testing.T) {
+	now := time.Now()
+	duration := -time.Second
+
+	defer func() {
+		lowerErr, ok := recover().(*chronological.LowerError)
+		if assert.True(t, ok) {
+			assert.Equal(t, "b", lowerErr.Argument)
+		}
+	}()
+	chronological.Optional(&now, &duration)
+}
+
 func TestChronologicalWorks(t *testing.T) {
 	assert.Equal(
 		t,
@@ -120,3 +144,9 @@ func TestTimeAndDurationOptionals(t *testing.T) {
 	assert.False(t, chronological.Optional(nil, &duration))
 	assert.False(t, chronological.Optional(&now, nil))
 }
+
+func TestWholeNegativeSecondsTimestampWrite(t *testing.T) {
+	var buffer bytes.Buffer
+	chronological.FfiConverterTimestampINSTANCE.Write(&buffer, time.Unix(-2, 0))
+	assert.Equal(t, []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xfe, 0, 0, 0, 0}, buffer.Bytes())
+}
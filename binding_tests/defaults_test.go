@@ -0,0 +1,66 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package binding_tests
+
+import (
+	"testing"
+
+	"github.com/NordSecurity/uniffi-bindgen-go/binding_tests/generated/defaults"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecordDefaults(t *testing.T) {
+	order := defaults.NewOrderDefault("tea")
+	assert.Equal(t, defaults.Order{
+		Item:     "tea",
+		Quantity: 1,
+		Offset:   -7,
+		Discount: 0.25,
+		Gift:     false,
+		Note:     "Handle with care",
+		Coupon:   nil,
+		Tags:     nil,
+		Priority: defaults.PriorityNormal,
+	}, order)
+	assert.Equal(t, order, defaults.EchoOrder(order))
+}
+
+func TestRecordWithOnlyDefaults(t *testing.T) {
+	settings := defaults.NewSettingsDefault()
+	assert.Equal(t, defaults.Settings{Retries: 3, Verbose: true}, settings)
+	assert.Equal(t, settings, defaults.EchoSettings(settings))
+}
+
+func TestFunctionOptions(t *testing.T) {
+	assert.Equal(t, "Hello, Go!", defaults.GreetWithOptions("Go"))
+	assert.Equal(t, "Hi, Go! Hi, Go!", defaults.GreetWithOptions("Go", defaults.GreetWithGreeting("Hi"), defaults.GreetWithTimes(2)))
+	assert.Equal(t, "Hello, Go! Hello, Go!", defaults.GreetWithOptions("Go", defaults.GreetWithTimes(2)))
+	assert.Equal(t, defaults.Greet("Go", "Hey", 1), defaults.GreetWithOptions("Go", defaults.GreetWithGreeting("Hey")))
+}
+
+func TestConstructorOptions(t *testing.T) {
+	shop := defaults.NewShopWithOptions("Market")
+	defer shop.Destroy()
+	assert.Equal(t, "Market", shop.Name())
+	assert.Equal(t, uint32(10), shop.Stock())
+
+	stocked := defaults.NewShopWithOptions("Market", defaults.NewShopWithStock(3))
+	defer stocked.Destroy()
+	assert.Equal(t, uint32(3), stocked.Stock())
+
+	corner := defaults.ShopWithStockWithOptions(5)
+	defer corner.Destroy()
+	assert.Equal(t, "Corner shop", corner.Name())
+	assert.Equal(t, uint32(5), corner.Stock())
+}
+
+func TestMethodOptions(t *testing.T) {
+	shop := defaults.NewShop("Market", 10)
+	defer shop.Destroy()
+
+	assert.Equal(t, "1 x tea", shop.CheckoutWithOptions("tea"))
+	assert.Equal(t, "2 x tea, gift wrapped", shop.CheckoutWithOptions("tea", defaults.ShopCheckoutWithGift(true), defaults.ShopCheckoutWithQuantity(2)))
+	assert.Equal(t, uint32(7), shop.Stock())
+}
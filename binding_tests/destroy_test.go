@@ -5,7 +5,9 @@
 package binding_tests
 
 import (
+	"runtime"
 	"testing"
+	"time"
 
 	"github.com/NordSecurity/uniffi-bindgen-go/binding_tests/generated/destroy"
 	"github.com/stretchr/testify/assert"
@@ -60,3 +62,122 @@ func TestDestroyEnum(t *testing.T) {
 	journal.Destroy()
 	assert.Equal(t, int32(0), destroy.GetLiveCount())
 }
+
+func TestCleanupReleasesUnreachableObject(t *testing.T) {
+	func() {
+		resources := []*destroy.Resource{
+			destroy.NewResource(),
+			destroy.NewResource(),
+		}
+		assert.Equal(t, int32(2), destroy.GetLiveCount())
+		runtime.KeepAlive(resources)
+	}()
+
+	assert.Eventually(t, func() bool {
+		runtime.GC()
+		return destroy.GetLiveCount() == 0
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestCleanupAfterDestroy(t *testing.T) {
+	func() {
+		resource := destroy.NewResource()
+		resource.Destroy()
+		assert.Equal(t, int32(0), destroy.GetLiveCount())
+	}()
+
+	// The cleanup was stopped by Destroy(), collecting the object must not free it again
+	for i := 0; i < 3; i++ {
+		runtime.GC()
+	}
+	time.Sleep(10 * time.Millisecond)
+	assert.Equal(t, int32(0), destroy.GetLiveCount())
+}
+
+func TestUseAfterDestroyReturnsError(t *testing.T) {
+	resource := destroy.NewResource()
+	alive, err := resource.IsAlive()
+	assert.NoError(t, err)
+	assert.True(t, alive)
+	assert.ErrorIs(t, resource.CheckOut(true), destroy.ErrResourceErrorBusy)
+
+	resource.Destroy()
+
+	alive, err = resource.IsAlive()
+	assert.False(t, alive)
+	assert.ErrorIs(t, err, destroy.ErrObjectDestroyed)
+	assert.EqualError(t, err, "*Resource object has already been destroyed")
+	assert.ErrorIs(t, resource.CheckOut(false), destroy.ErrObjectDestroyed)
+}
+
+func TestFailedLowerReleasesWrittenHandles(t *testing.T) {
+	journal := destroy.CreateJournal()
+	resource := destroy.NewResource()
+	journal.Object = &resource
+	// The object is written before the duration fails to lower
+	negative := -time.Second
+	journal.Duration = &negative
+
+	assert.PanicsWithError(t, "cannot lower argument journal: negative duration -1s is not allowed", func() {
+		destroy.KeepJournal(journal, time.Second)
+	})
+	resource.Destroy()
+	assert.Equal(t, int32(0), destroy.GetLiveCount())
+}
+
+func TestFailedLowerReleasesPreviousArguments(t *testing.T) {
+	journal := destroy.CreateJournal()
+	resource := destroy.NewResource()
+	journal.Object = &resource
+
+	assert.Panics(t, func() {
+		destroy.KeepJournal(journal, -time.Second)
+	})
+	resource.Destroy()
+	assert.Equal(t, int32(0), destroy.GetLiveCount())
+}
+
+func TestFailedLowerReleasesReceiver(t *testing.T) {
+	resource := destroy.NewResource()
+
+	var lowerErr *destroy.LowerError
+	assert.ErrorAs(t, resource.Lend(-time.Second), &lowerErr)
+	resource.Destroy()
+	assert.Equal(t, int32(0), destroy.GetLiveCount())
+}
+
+func TestDestroyedArgumentReleasesPreviousArguments(t *testing.T) {
+	journal := destroy.CreateJournal()
+	resource := destroy.NewResource()
+	journal.Object = &resource
+	destroyed := destroy.NewResource()
+	destroyed.Destroy()
+
+	defer func() {
+		err, _ := recover().(error)
+		var lowerErr *destroy.LowerError
+		assert.ErrorAs(t, err, &lowerErr)
+		assert.ErrorIs(t, err, destroy.ErrObjectDestroyed)
+
+		resource.Destroy()
+		assert.Equal(t, int32(0), destroy.GetLiveCount())
+	}()
+	destroy.KeepResource(journal, destroyed)
+}
+
+func TestDestroyedArgumentReturnsError(t *testing.T) {
+	journal := destroy.CreateJournal()
+	resource := destroy.NewResource()
+	journal.Object = &resource
+	destroyed := destroy.NewResource()
+	destroyed.Destroy()
+	owner := destroy.NewResource()
+
+	err := owner.HandOver(journal, destroyed)
+	assert.EqualError(t, err, "cannot lower argument successor: *Resource object has already been destroyed")
+	assert.ErrorIs(t, err, destroy.ErrObjectDestroyed)
+
+	owner.Destroy()
+	resource.Destroy()
+	assert.Equal(t, int32(0), destroy.GetLiveCount())
+}
@@ -5,8 +5,11 @@
 package binding_tests
 
 import (
+	"bytes"
+	"encoding/json"
 	goerrors "errors"
 	"fmt"
+	"log/slog"
 	"testing"
 
 	"github.com/NordSecurity/uniffi-bindgen-go/binding_tests/generated/errors"
@@ -164,3 +167,107 @@ func TestNestedError(t *testing.T) {
 	assert.ErrorAs(t, expectedError.Unwrap(), &expectedNestedError)
 	assert.Equal(t, "ValidationError: UnknownError", expectedNestedError.Source.Error())
 }
+
+func TestErrorVariantAccessors(t *testing.T) {
+	err := errors.ValidateMessage(100, "byebye")
+	var validationError *errors.ValidationError
+	if !assert.ErrorAs(t, err, &validationError) {
+		return
+	}
+
+	assert.Equal(t, errors.ValidationErrorVariantInvalidUserAndMessage, validationError.Variant())
+
+	variant, ok := validationError.AsInvalidUserAndMessage()
+	if assert.True(t, ok) {
+		assert.Equal(t, int32(100), variant.UserId)
+		assert.Equal(t, "byebye", variant.Message)
+	}
+
+	_, ok = validationError.AsInvalidUser()
+	assert.False(t, ok)
+
+	namedError := errors.NewErrorNamedErrorError("it's an error")
+	errorVariant, ok := namedError.AsError_()
+	if assert.True(t, ok) {
+		assert.Equal(t, "it's an error", errorVariant.Error_)
+	}
+}
+
+func TestErrorVariantThroughWrapping(t *testing.T) {
+	err := fmt.Errorf("validating: %w", errors.ValidateMessage(100, ""))
+
+	assert.ErrorIs(t, err, errors.ErrValidationErrorInvalidUser)
+	assert.ErrorIs(t, err, errors.NewValidationErrorInvalidUser(1))
+	assert.ErrorIs(t, err, fmt.Errorf("other: %w", errors.NewValidationErrorInvalidUser(1)))
+	assert.NotErrorIs(t, err, errors.NewValidationErrorInvalidMessage("hello"))
+
+	var pointerVariant *errors.ValidationErrorInvalidUser
+	if assert.ErrorAs(t, err, &pointerVariant) {
+		assert.Equal(t, int32(100), pointerVariant.UserId)
+	}
+
+	var valueVariant errors.ValidationErrorInvalidUser
+	if assert.ErrorAs(t, err, &valueVariant) {
+		assert.Equal(t, int32(100), valueVariant.UserId)
+	}
+}
+
+func logValue(t *testing.T, value any) any {
+	var buf bytes.Buffer
+	slog.New(slog.NewJSONHandler(&buf, nil)).Info("test", "value", value)
+
+	var entry map[string]any
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+	return entry["value"]
+}
+
+func logAttrs(t *testing.T, value any) map[string]any {
+	attrs, _ := logValue(t, value).(map[string]any)
+	return attrs
+}
+
+func TestRecordLogValue(t *testing.T) {
+	attrs := logAttrs(t, errors.Vec2{X: 1, Y: 2.5})
+	assert.Equal(t, map[string]any{"x": 1.0, "y": 2.5}, attrs)
+}
+
+func TestErrorLogValue(t *testing.T) {
+	attrs := logAttrs(t, errors.TryVoid(true))
+	assert.Equal(t, map[string]any{
+		"variant": "IceSlip",
+		"message": "You slipped on deliberately poured ice",
+	}, attrs)
+
+	attrs = logAttrs(t, errors.ValidateMessage(100, ""))
+	assert.Equal(t, map[string]any{"variant": "InvalidUser", "user_id": 100.0}, attrs)
+
+	attrs = logAttrs(t, errors.GetComplexError("option"))
+	assert.Equal(t, map[string]any{"variant": "Option", "id_a": 123.0, "id_b": nil}, attrs)
+}
+
+func TestErrorLogValueRedactsSensitiveFields(t *testing.T) {
+	attrs := logAttrs(t, errors.ValidateMessage(100, "secret"))
+	assert.Equal(t, map[string]any{
+		"variant": "InvalidUserAndMessage",
+		"user_id": 100.0,
+		"message": "[REDACTED]",
+	}, attrs)
+}
+
+func TestFlatEnumLogValue(t *testing.T) {
+	assert.Equal(t, "High", logValue(t, errors.SeverityHigh))
+	// Values unknown to the bindings log their discriminant
+	assert.Equal(t, 7.0, logValue(t, errors.Severity(7)))
+}
+
+func TestTaggedEnumLogValue(t *testing.T) {
+	attrs := logAttrs(t, errors.HazardAt("bridge"))
+	assert.Equal(t, map[string]any{
+		"variant":  "Ice",
+		"severity": "High",
+		"location": "[REDACTED]",
+	}, attrs)
+
+	attrs = logAttrs(t, errors.HazardAt(""))
+	assert.Equal(t, map[string]any{"variant": "Clear"}, attrs)
+}
@@ -1,6 +1,7 @@
 package binding_tests
 
 import (
+	goerrors "errors"
 	"fmt"
 	"strings"
 	"testing"
@@ -75,6 +76,18 @@ func (invalidGetters) GetNothing(v string) error {
 	return fixture_callbacks.NewSimpleErrorBadArgument()
 }
 
+type failingGetters struct{ getters }
+
+func (failingGetters) GetOption(v *string, arg2 bool) (*string, error) {
+	return nil, goerrors.New("not a ComplexError")
+}
+
+type panickingGetters struct{ getters }
+
+func (panickingGetters) GetOption(v *string, arg2 bool) (*string, error) {
+	panic("getter exploded")
+}
+
 type goStringifier struct{}
 
 func (goStringifier) FromSimpleType(value int32) string {
@@ -352,3 +365,24 @@ func TestRustGetters_CallbackReferenceDoesNotInvalidateOtherReferences(t *testin
 	assert.Equal(t, "Go: 321", rustStringifier1.FromSimpleType(321))
 	rustStringifier1.Destroy()
 }
+
+func TestRustGetters_UnexpectedErrorMessageIsPropagated(t *testing.T) {
+	res, err := fixture_callbacks.NewRustGetters().GetOption(failingGetters{}, nil, false)
+	assert.Nil(t, res)
+
+	var unexpected *fixture_callbacks.ComplexErrorUnexpectedErrorWithReason
+	if assert.ErrorAs(t, err, &unexpected) {
+		assert.Equal(t, "not a ComplexError", unexpected.Reason)
+	}
+}
+
+func TestRustGetters_CallbackPanicIsPropagated(t *testing.T) {
+	res, err := fixture_callbacks.NewRustGetters().GetOption(panickingGetters{}, nil, false)
+	assert.Nil(t, res)
+
+	var unexpected *fixture_callbacks.ComplexErrorUnexpectedErrorWithReason
+	if assert.ErrorAs(t, err, &unexpected) {
+		assert.Contains(t, unexpected.Reason, "Go callback panicked: getter exploded")
+		assert.Contains(t, unexpected.Reason, "panickingGetters.GetOption")
+	}
+}
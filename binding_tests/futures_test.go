@@ -5,9 +5,11 @@
 package binding_tests
 
 import (
+	"context"
 	"fmt"
 	"strconv"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -381,3 +383,385 @@ func TestFuturesLockAndNotCancel(t *testing.T) {
 
 	UseSharedResource(SharedResourceOptions{ReleaseAfterMs: 0, TimeoutMs: 1000})
 }
+
+func TestFuturesCtxCompletes(t *testing.T) {
+	ctx := context.Background()
+
+	t0 := time.Now()
+	result, err := SayAfterCtx(ctx, 100, "Alice")
+	assertDelayedExecution(t, t0, 100*time.Millisecond)
+	assert.NoError(t, err)
+	assert.Equal(t, "Hello, Alice!", result)
+
+	megaphone, err := NewMegaphoneCtx(ctx)
+	assert.NoError(t, err)
+	result, err = megaphone.SayAfterCtx(ctx, 20, "Alice")
+	assert.NoError(t, err)
+	assert.Equal(t, "HELLO, ALICE!", result)
+
+	megaphone, err = MegaphoneSecondaryCtx(ctx)
+	assert.NoError(t, err)
+	assert.NotNil(t, megaphone)
+
+	assert.NoError(t, VoidCtx(ctx))
+}
+
+func TestFuturesCtxFallible(t *testing.T) {
+	ctx := context.Background()
+
+	result, err := FallibleMeCtx(ctx, false)
+	assert.NoError(t, err)
+	assert.Equal(t, uint8(42), result)
+
+	_, err = FallibleMeCtx(ctx, true)
+	assert.EqualError(t, err, "MyError: Foo")
+	assert.NotErrorIs(t, err, context.Canceled)
+
+	megaphone := NewMegaphone()
+	_, err = megaphone.FallibleMeCtx(ctx, true)
+	assert.EqualError(t, err, "MyError: Foo")
+}
+
+func TestFuturesCtxDeadline(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	t0 := time.Now()
+	_, err := SleepCtx(ctx, 1000)
+	assertDelayedExecution(t, t0, 50*time.Millisecond)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// Already done context never gets to run the future
+	_, err = NewMegaphone().SayAfterCtx(ctx, 1000, "Alice")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestFuturesCtxAlreadyCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// A future that is ready on the first poll must not race the done context
+	for i := 0; i < 100; i++ {
+		result, err := AlwaysReadyCtx(ctx)
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.False(t, result)
+	}
+}
+
+func TestFuturesCtxLockAndCancel(t *testing.T) {
+	// Cancelling the context drops the Rust future, which releases the shared resource.
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		cancel()
+	}()
+
+	err := UseSharedResourceCtx(ctx, SharedResourceOptions{ReleaseAfterMs: 5000, TimeoutMs: 100})
+	assert.ErrorIs(t, err, context.Canceled)
+
+	err = UseSharedResource(SharedResourceOptions{ReleaseAfterMs: 0, TimeoutMs: 1000})
+	assert.NoError(t, err)
+}
+
+type goCancellableWorker struct {
+	interrupted atomic.Bool
+}
+
+func (w *goCancellableWorker) Work(ctx context.Context, delayMs int32) bool {
+	select {
+	case <-time.After(time.Duration(delayMs) * time.Millisecond):
+		return true
+	case <-ctx.Done():
+		w.interrupted.Store(true)
+		return false
+	}
+}
+
+func (w *goCancellableWorker) Rest(ctx context.Context, delay time.Duration) {}
+
+func TestFuturesForeignAsyncTraitContext(t *testing.T) {
+	worker := &goCancellableWorker{}
+
+	t0 := time.Now()
+	assert.True(t, WorkUsingTrait(worker, 20))
+	assertDelayedExecution(t, t0, 20*time.Millisecond)
+	assert.False(t, worker.interrupted.Load())
+
+	// Dropping the future on the Rust side cancels the context passed to Go
+	CancelWorkUsingTrait(worker, 1000)
+	assert.Eventually(t, worker.interrupted.Load, 100*time.Millisecond, time.Millisecond)
+}
+
+func TestFuturesRustAsyncTraitContext(t *testing.T) {
+	worker := NewRustWorker()
+	assert.True(t, worker.Work(context.Background(), 10))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	t0 := time.Now()
+	assert.False(t, worker.Work(ctx, 1000))
+	assertDelayedExecution(t, t0, 20*time.Millisecond)
+
+	_, err := worker.(*CancellableWorkerImpl).WorkCtx(ctx, 1000)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestFuturesRustAsyncTraitContextPanicsOnOtherErrors(t *testing.T) {
+	worker := NewRustWorker()
+	worker.Rest(context.Background(), time.Millisecond)
+
+	// Only a done ctx is swallowed by methods without an error result
+	assert.PanicsWithError(t, "cannot lower argument delay: negative duration -1s is not allowed", func() {
+		worker.Rest(context.Background(), -time.Second)
+	})
+}
+
+func TestFuturesFutureFanOut(t *testing.T) {
+	t0 := time.Now()
+	alice := SayAfterFuture(100, "Alice")
+	bob := SayAfterFuture(200, "Bob")
+	megaphone := NewMegaphone().SayAfterFuture(150, "Carol")
+
+	resultAlice, err := alice.Result()
+	assert.NoError(t, err)
+	resultBob, err := bob.Wait(context.Background())
+	assert.NoError(t, err)
+	<-megaphone.Done()
+	resultCarol, err := megaphone.Result()
+	assert.NoError(t, err)
+
+	assertDelayedExecution(t, t0, 200*time.Millisecond)
+	assert.Equal(t, "Hello, Alice!", resultAlice)
+	assert.Equal(t, "Hello, Bob!", resultBob)
+	assert.Equal(t, "HELLO, CAROL!", resultCarol)
+}
+
+func TestFuturesFutureSelect(t *testing.T) {
+	slow := SleepFuture(1000)
+	fast := SleepFuture(10)
+
+	select {
+	case <-slow.Done():
+		assert.Fail(t, "slow future finished first")
+	case <-fast.Done():
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err := slow.Wait(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	slow.Cancel()
+	_, err = slow.Result()
+	assert.ErrorIs(t, err, context.Canceled)
+
+	// Cancelling a finished future has no effect
+	fast.Cancel()
+	result, err := fast.Result()
+	assert.NoError(t, err)
+	assert.True(t, result)
+}
+
+func TestFuturesFutureErrors(t *testing.T) {
+	_, err := FallibleMeFuture(true).Result()
+	assert.EqualError(t, err, "MyError: Foo")
+
+	result, err := FallibleMeFuture(false).Result()
+	assert.NoError(t, err)
+	assert.Equal(t, uint8(42), result)
+
+	megaphone, err := NewMegaphoneFuture().Result()
+	assert.NoError(t, err)
+	_, err = megaphone.FallibleMeFuture(true).Result()
+	assert.EqualError(t, err, "MyError: Foo")
+
+	_, err = VoidFuture().Result()
+	assert.NoError(t, err)
+}
+
+func BenchmarkFuturesAlwaysReady(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		AlwaysReady()
+	}
+}
+
+func BenchmarkFuturesSayAfter(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		SayAfter(0, "Alice")
+	}
+}
+
+// Goes through a real wakeup from the timer thread
+func BenchmarkFuturesSleep(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		Sleep(1)
+	}
+}
+
+func BenchmarkFuturesAlwaysReadyParallel(b *testing.B) {
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			AlwaysReady()
+		}
+	})
+}
+
+func BenchmarkFuturesAlwaysReadyFuture(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		AlwaysReadyFuture().Result()
+	}
+}
+
+// A blocking async call that is ready right away reuses a pooled waiter, so only the call
+// status is left on the heap. A channel and a cgo.Handle per call would exceed the bound.
+func TestFuturesAlwaysReadyAllocations(t *testing.T) {
+	allocs := testing.AllocsPerRun(1000, func() {
+		AlwaysReady()
+	})
+	assert.LessOrEqual(t, allocs, float64(2))
+}
+
+// Holds calls with the "gated" value until released, to keep a callback executor busy
+type gatedAsyncParser struct {
+	goAsyncParser
+	started chan struct{}
+	release chan struct{}
+}
+
+func (gap *gatedAsyncParser) TryFromString(delayMs int32, value string) (int32, error) {
+	if value == "gated" {
+		gap.started <- struct{}{}
+		<-gap.release
+		value = "1"
+	}
+	return gap.goAsyncParser.TryFromString(delayMs, value)
+}
+
+func TestFuturesBoundedCallbackExecutor(t *testing.T) {
+	executor := NewBoundedCallbackExecutor(1, 0, CallbackQueueFullReject)
+	SetCallbackExecutor(executor)
+	defer func() {
+		SetCallbackExecutor(nil)
+		executor.Close()
+	}()
+
+	traitObj := &gatedAsyncParser{
+		started: make(chan struct{}, 1),
+		release: make(chan struct{}),
+	}
+
+	// Keep the only worker busy, there is no room in the queue for anything else
+	busy := TryFromStringUsingTraitFuture(traitObj, 0, "gated")
+	<-traitObj.started
+
+	_, err := TryFromStringUsingTrait(traitObj, 0, "2")
+	assert.ErrorIs(t, err, ErrParserErrorUnexpectedError)
+
+	close(traitObj.release)
+	val, err := busy.Result()
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), val)
+
+	// The result can arrive before the worker is back at the queue
+	assert.Eventually(t, func() bool {
+		val, err = TryFromStringUsingTrait(traitObj, 0, "3")
+		return err == nil
+	}, time.Second, time.Millisecond)
+	assert.Equal(t, int32(3), val)
+}
+
+func TestFuturesBoundedCallbackExecutorCloseUnblocksSubmitters(t *testing.T) {
+	executor := NewBoundedCallbackExecutor(1, 0, CallbackQueueFullBlock)
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	assert.NoError(t, executor.Execute(func() {
+		close(started)
+		<-release
+	}))
+	<-started
+
+	// The only worker is busy, so this blocks until the executor is closed
+	blocked := make(chan error)
+	go func() {
+		blocked <- executor.Execute(func() {})
+	}()
+
+	closed := make(chan struct{})
+	go func() {
+		executor.Close()
+		close(closed)
+	}()
+
+	assert.ErrorIs(t, <-blocked, ErrCallbackExecutorClosed)
+	assert.ErrorIs(t, executor.Execute(func() {}), ErrCallbackExecutorClosed)
+
+	// Close still waits for the running task
+	select {
+	case <-closed:
+		t.Fatal("Close returned before the running task finished")
+	default:
+	}
+	close(release)
+	<-closed
+}
+
+func TestFuturesStreamAll(t *testing.T) {
+	ctx := context.Background()
+
+	var items []uint32
+	for item, err := range NewCountdown(3, nil).All(ctx) {
+		assert.NoError(t, err)
+		items = append(items, item)
+	}
+	assert.Equal(t, []uint32{2, 1, 0}, items)
+
+	failAt := uint32(1)
+	items = nil
+	var streamErr error
+	for item, err := range NewCountdown(3, &failAt).All(ctx) {
+		if err != nil {
+			streamErr = err
+			break
+		}
+		items = append(items, item)
+	}
+	assert.Equal(t, []uint32{2}, items)
+	assert.EqualError(t, streamErr, "MyError: Foo")
+
+	// Stopping early destroys the stream as well
+	countdown := NewCountdown(10, nil)
+	for range countdown.All(ctx) {
+		break
+	}
+	assert.PanicsWithError(t, "*Countdown object has already been destroyed", func() {
+		countdown.Tick()
+	})
+}
+
+func TestFuturesStreamChan(t *testing.T) {
+	var items []uint32
+	for item := range NewCountdown(3, nil).Chan(context.Background()) {
+		items = append(items, item)
+	}
+	assert.Equal(t, []uint32{2, 1, 0}, items)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	countdown := NewCountdown(1000, nil)
+	items = nil
+	for item := range countdown.Chan(ctx) {
+		items = append(items, item)
+		if len(items) == 2 {
+			cancel()
+		}
+	}
+	assert.Less(t, len(items), 5)
+	assert.PanicsWithError(t, "*Countdown object has already been destroyed", func() {
+		countdown.Tick()
+	})
+}
@@ -0,0 +1,65 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package binding_tests
+
+import (
+	"os"
+	"os/exec"
+	"testing"
+
+	"github.com/NordSecurity/uniffi-bindgen-go/binding_tests/generated/utf8"
+	"github.com/stretchr/testify/assert"
+)
+
+// The utf8 fixture is generated with `deferred_initialization = true`
+
+func TestInitializeIsIdempotent(t *testing.T) {
+	assert.NoError(t, utf8.Initialize())
+	assert.NoError(t, utf8.Initialize())
+}
+
+func TestEntryPointsInitializeLazily(t *testing.T) {
+	if os.Getenv("UNIFFI_LAZY_INIT_CHILD") == "1" {
+		// Greet is the first call into utf8 in this process, it needs the callback interface
+		// registered by Initialize
+		assert.Equal(t, "Hello, lazy", utf8.Greet(nameSource{"lazy"}))
+		return
+	}
+
+	// Other tests already initialized utf8 in this process, so the test runs in a fresh one
+	cmd := exec.Command(os.Args[0], "-test.run=^TestEntryPointsInitializeLazily$", "-test.v")
+	cmd.Env = append(os.Environ(), "UNIFFI_LAZY_INIT_CHILD=1")
+	output, err := cmd.CombinedOutput()
+	assert.NoError(t, err, string(output))
+	assert.Contains(t, string(output), "--- PASS: TestEntryPointsInitializeLazily")
+}
+
+func TestContractMismatchErrorListsEverySymbol(t *testing.T) {
+	err := &utf8.ContractMismatchError{
+		Namespace:                  "utf8",
+		BindingsContractVersion:    30,
+		ScaffoldingContractVersion: 30,
+		MismatchedChecksums: []utf8.ChecksumMismatch{
+			{
+				Symbol:    "uniffi_utf8_checksum_func_greet",
+				Kind:      "function",
+				Name:      "Greet",
+				Signature: "(source NameSource) string",
+			},
+			{
+				Symbol:    "uniffi_utf8_checksum_method_namesource_name",
+				Kind:      "callback method",
+				Object:    "NameSource",
+				Name:      "Name",
+				Signature: "() string",
+			},
+		},
+	}
+	assert.Equal(t, "utf8: UniFFI API checksum mismatch: function Greet(source NameSource) string; "+
+		"callback method NameSource.Name() string", err.Error())
+
+	err = &utf8.ContractMismatchError{Namespace: "utf8", BindingsContractVersion: 30, ScaffoldingContractVersion: 29}
+	assert.Equal(t, "utf8: UniFFI contract version mismatch: bindings have 30, library has 29", err.Error())
+}
@@ -0,0 +1,103 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package binding_tests
+
+import (
+	"bytes"
+	"testing"
+	"time"
+	"unsafe"
+
+	"github.com/NordSecurity/uniffi-bindgen-go/binding_tests/generated/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+// Buffer handed to the generated lifters without going through Rust
+type goOwnedRustBuffer []byte
+
+func (b goOwnedRustBuffer) AsReader() *bytes.Reader { return bytes.NewReader(b) }
+func (b goOwnedRustBuffer) Free()                   {}
+func (b goOwnedRustBuffer) ToGoBytes() []byte       { return b }
+func (b goOwnedRustBuffer) Data() unsafe.Pointer    { return unsafe.Pointer(unsafe.SliceData(b)) }
+func (b goOwnedRustBuffer) Len() uint64             { return uint64(len(b)) }
+func (b goOwnedRustBuffer) Capacity() uint64        { return uint64(len(b)) }
+
+// Lifts data, returning the *LiftError it panicked with, if any
+func liftBuffer[T any](reader errors.BufReader[T], data []byte) (liftErr *errors.LiftError) {
+	defer func() {
+		if r := recover(); r != nil {
+			var ok bool
+			if liftErr, ok = r.(*errors.LiftError); !ok {
+				panic(r)
+			}
+		}
+	}()
+	errors.LiftFromRustBuffer[T](reader, goOwnedRustBuffer(data))
+	return nil
+}
+
+func TestLiftUnknownErrorCode(t *testing.T) {
+	liftErr := liftBuffer[*errors.BoobyTrapError](errors.FfiConverterBoobyTrapErrorINSTANCE,
+		[]byte{0, 0, 0, 9, 0, 0, 0, 0})
+	if assert.NotNil(t, liftErr) {
+		assert.Equal(t, "BoobyTrapError", liftErr.TypeName)
+		assert.Equal(t, int64(8), liftErr.Offset)
+		assert.Contains(t, liftErr.Reason, "unknown error code 9")
+	}
+}
+
+func TestLiftTruncatedBuffer(t *testing.T) {
+	liftErr := liftBuffer[errors.Vec2](errors.FfiConverterVec2INSTANCE, []byte{0, 0, 0, 0, 0, 0, 0, 0, 1})
+	if assert.NotNil(t, liftErr) {
+		assert.Equal(t, "errors.Vec2", liftErr.TypeName)
+		assert.Equal(t, int64(9), liftErr.Offset)
+	}
+}
+
+func TestLiftStringLongerThanBuffer(t *testing.T) {
+	liftErr := liftBuffer[string](errors.FfiConverterStringINSTANCE, []byte{0x7f, 0xff, 0xff, 0xff, 'a'})
+	if assert.NotNil(t, liftErr) {
+		assert.Equal(t, "string", liftErr.TypeName)
+		assert.Contains(t, liftErr.Reason, "exceeds")
+	}
+}
+
+func TestLiftJunkRemaining(t *testing.T) {
+	liftErr := liftBuffer[*time.Time](errors.FfiConverterOptionalTimestampINSTANCE, []byte{0, 'j', 'u', 'n', 'k'})
+	if assert.NotNil(t, liftErr) {
+		assert.Equal(t, int64(1), liftErr.Offset)
+		assert.Contains(t, liftErr.Reason, "4 bytes remaining")
+	}
+}
+
+// Random buffers either lift, or fail with a *LiftError pointing into the buffer
+func FuzzLiftFromRustBuffer(f *testing.F) {
+	f.Add([]byte{})
+	f.Add([]byte{0, 0, 0, 1})
+	f.Add([]byte{0, 0, 0, 3, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0})
+	f.Add([]byte{0xff, 0xff, 0xff, 0xff})
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		liftErrors := []*errors.LiftError{
+			liftBuffer[*errors.BoobyTrapError](errors.FfiConverterBoobyTrapErrorINSTANCE, data),
+			liftBuffer[*errors.NestedError](errors.FfiConverterNestedErrorINSTANCE, data),
+			liftBuffer[*errors.ValidationError](errors.FfiConverterValidationErrorINSTANCE, data),
+			liftBuffer[*errors.ErrorNamedError](errors.FfiConverterErrorNamedErrorINSTANCE, data),
+			liftBuffer[*errors.ComplexError](errors.FfiConverterComplexErrorINSTANCE, data),
+			liftBuffer[errors.Vec2](errors.FfiConverterVec2INSTANCE, data),
+			liftBuffer[[]errors.Vec2](errors.FfiConverterSequenceVec2INSTANCE, data),
+			liftBuffer[map[int32]errors.Vec2](errors.FfiConverterMapInt32Vec2INSTANCE, data),
+			liftBuffer[*time.Time](errors.FfiConverterOptionalTimestampINSTANCE, data),
+			liftBuffer[string](errors.FfiConverterStringINSTANCE, data),
+		}
+		for _, liftErr := range liftErrors {
+			if liftErr != nil {
+				assert.NotEmpty(t, liftErr.TypeName)
+				assert.GreaterOrEqual(t, liftErr.Offset, int64(0))
+				assert.LessOrEqual(t, liftErr.Offset, int64(len(data)))
+			}
+		}
+	})
+}
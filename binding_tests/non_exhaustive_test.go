@@ -0,0 +1,123 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package binding_tests
+
+import (
+	goerrors "errors"
+	"testing"
+
+	"github.com/NordSecurity/uniffi-bindgen-go/binding_tests/generated/non_exhaustive"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKnownVariantsStillWork(t *testing.T) {
+	assert.Equal(t, non_exhaustive.DirectionSouth, non_exhaustive.Opposite(non_exhaustive.DirectionNorth))
+	assert.False(t, non_exhaustive.DirectionNorth.IsUnknown())
+	assert.Equal(t, "square of side 2", non_exhaustive.Describe(non_exhaustive.ShapeSquare{Side: 2}))
+
+	err := non_exhaustive.Fetch(true)
+	assert.ErrorIs(t, err, non_exhaustive.ErrFetchErrorTimeout)
+	var fetchErr *non_exhaustive.FetchError
+	if assert.True(t, goerrors.As(err, &fetchErr)) {
+		assert.False(t, fetchErr.IsUnknown())
+	}
+}
+
+func TestUnknownFlatEnumKeepsDiscriminant(t *testing.T) {
+	direction := non_exhaustive.LiftFromRustBuffer[non_exhaustive.Direction](
+		non_exhaustive.FfiConverterDirectionINSTANCE, goOwnedRustBuffer{0, 0, 0, 3})
+	assert.True(t, direction.IsUnknown())
+	assert.Equal(t, non_exhaustive.Direction(3), direction)
+}
+
+func TestUnknownFlatEnumCanNotBeLowered(t *testing.T) {
+	defer func() {
+		lowerErr, ok := recover().(*non_exhaustive.LowerError)
+		if assert.True(t, ok) {
+			assert.Equal(t, "direction", lowerErr.Argument)
+		}
+	}()
+	non_exhaustive.Opposite(non_exhaustive.Direction(3))
+}
+
+func TestUnknownTaggedEnumVariant(t *testing.T) {
+	shape := non_exhaustive.LiftFromRustBuffer[non_exhaustive.Shape](
+		non_exhaustive.FfiConverterShapeINSTANCE, goOwnedRustBuffer{0, 0, 0, 3, 1, 2, 3, 4, 5, 6, 7, 8})
+	assert.Equal(t, non_exhaustive.ShapeUnknownVariant{Discriminant: 3}, shape)
+}
+
+func TestUnknownFlatErrorVariant(t *testing.T) {
+	err := non_exhaustive.LiftFromRustBuffer[*non_exhaustive.FetchError](
+		non_exhaustive.FfiConverterFetchErrorINSTANCE, goOwnedRustBuffer{0, 0, 0, 2, 0, 0, 0, 2, 'h', 'i'})
+	assert.True(t, err.IsUnknown())
+	assert.Equal(t, non_exhaustive.FetchErrorVariant(2), err.Variant())
+	assert.ErrorIs(t, err, non_exhaustive.ErrFetchErrorUnknownVariant)
+	assert.Equal(t, "FetchError: UnknownVariant(2): hi", err.Error())
+}
+
+func TestUnknownErrorVariant(t *testing.T) {
+	err := non_exhaustive.LiftFromRustBuffer[*non_exhaustive.ParseError](
+		non_exhaustive.FfiConverterParseErrorINSTANCE, goOwnedRustBuffer{0, 0, 0, 2, 0, 0, 0, 7})
+	var unknown non_exhaustive.ParseErrorUnknownVariant
+	if assert.True(t, goerrors.As(err, &unknown)) {
+		assert.Equal(t, int32(2), unknown.Discriminant)
+	}
+	assert.False(t, goerrors.Is(err, non_exhaustive.ErrParseErrorSyntax))
+}
+
+// Recovers the *LiftError raised by lift, if any
+func recoverLiftError(lift func()) (liftErr *non_exhaustive.LiftError) {
+	defer func() {
+		if r := recover(); r != nil {
+			var ok bool
+			if liftErr, ok = r.(*non_exhaustive.LiftError); !ok {
+				panic(r)
+			}
+		}
+	}()
+	lift()
+	return nil
+}
+
+func TestUnknownVariantEndingSequence(t *testing.T) {
+	shapes := non_exhaustive.LiftFromRustBuffer[[]non_exhaustive.Shape](
+		non_exhaustive.FfiConverterSequenceShapeINSTANCE, goOwnedRustBuffer{
+			0, 0, 0, 2,
+			0, 0, 0, 1, 0x3f, 0xf0, 0, 0, 0, 0, 0, 0,
+			0, 0, 0, 3, 1, 2, 3, 4,
+		})
+	assert.Equal(t, []non_exhaustive.Shape{
+		non_exhaustive.ShapeCircle{Radius: 1},
+		non_exhaustive.ShapeUnknownVariant{Discriminant: 3},
+	}, shapes)
+}
+
+func TestUnknownVariantFollowedBySequenceElement(t *testing.T) {
+	liftErr := recoverLiftError(func() {
+		non_exhaustive.LiftFromRustBuffer[[]non_exhaustive.Shape](
+			non_exhaustive.FfiConverterSequenceShapeINSTANCE, goOwnedRustBuffer{
+				0, 0, 0, 2,
+				0, 0, 0, 3, 1, 2, 3, 4,
+				0, 0, 0, 1, 0x3f, 0xf0, 0, 0, 0, 0, 0, 0,
+			})
+	})
+	if assert.NotNil(t, liftErr) {
+		assert.Contains(t, liftErr.Reason, "values after an unknown variant of Shape can not be read")
+	}
+}
+
+func TestUnknownVariantFollowedByRecordField(t *testing.T) {
+	liftErr := recoverLiftError(func() {
+		non_exhaustive.LiftFromRustBuffer[non_exhaustive.Drawing](
+			non_exhaustive.FfiConverterDrawingINSTANCE, goOwnedRustBuffer{
+				0, 0, 0, 3, 1, 2, 3, 4,
+				0, 0, 0, 2, 'h', 'i',
+			})
+	})
+	if assert.NotNil(t, liftErr) {
+		assert.Equal(t, "non_exhaustive.Drawing", liftErr.TypeName)
+		assert.Contains(t, liftErr.Reason, "values after an unknown variant of Shape can not be read")
+	}
+}
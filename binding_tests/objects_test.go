@@ -6,6 +6,8 @@ package binding_tests
 
 import (
 	"runtime"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -191,3 +193,101 @@ func TestAsyncFailureReturningRustBufferPropagatesError(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, "all-good", result)
 }
+
+func TestLowerNestedObjects(t *testing.T) {
+	object := objects.NewObject1("hello")
+	defer object.Destroy()
+
+	assert.Equal(t, "hello", objects.GetHolderMessage(objects.Object1Holder{Object: object}))
+	assert.Equal(t, []string{"hello", "hello"}, objects.GetMessages([]*objects.Object1{object, object}))
+	assert.Equal(t, map[string]string{"a": "hello"}, objects.GetMessageMap(map[string]*objects.Object1{"a": object}))
+	assert.Equal(t, "hello", object.GetMessage())
+}
+
+// Meant to be run with `GO_TEST_FLAGS=-race ./test_bindings.sh`. Every call either lowers the object before it is destroyed
+// and succeeds, or panics because it was already destroyed. Rust must never see a freed handle.
+func TestLowerRacesWithDestroy(t *testing.T) {
+	calls := []func(object *objects.Object1){
+		func(object *objects.Object1) {
+			returned := objects.ReturnObject1(object)
+			defer returned.Destroy()
+			assert.Equal(t, "hello", returned.GetMessage())
+		},
+		func(object *objects.Object1) {
+			assert.Equal(t, "hello", objects.GetHolderMessage(objects.Object1Holder{Object: object}))
+		},
+		func(object *objects.Object1) {
+			assert.Equal(t, []string{"hello", "hello"}, objects.GetMessages([]*objects.Object1{object, object}))
+		},
+		func(object *objects.Object1) {
+			assert.Equal(t, map[string]string{"a": "hello"}, objects.GetMessageMap(map[string]*objects.Object1{"a": object}))
+		},
+		func(object *objects.Object1) {
+			handle := objects.LowerToExternalObject1(object)
+			lifted := objects.LiftFromExternalObject1(handle)
+			defer lifted.Destroy()
+			assert.Equal(t, "hello", lifted.GetMessage())
+		},
+	}
+
+	for i := 0; i < 200; i++ {
+		object := objects.NewObject1("hello")
+
+		var wg sync.WaitGroup
+		for _, call := range calls {
+			wg.Add(1)
+			go func(call func(object *objects.Object1)) {
+				defer wg.Done()
+				defer func() {
+					if r := recover(); r != nil {
+						assert.Equal(t, "*Object1 object has already been destroyed", r.(error).Error())
+					}
+				}()
+				call(object)
+			}(call)
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			object.Destroy()
+		}()
+		wg.Wait()
+	}
+}
+
+func TestObjectTracking(t *testing.T) {
+	objects.SetObjectTracking(true)
+	defer objects.SetObjectTracking(false)
+
+	before := objects.LiveObjectCount("Object1")
+	object := objects.NewObject1("tracked")
+	assert.Equal(t, before+1, objects.LiveObjectCount("Object1"))
+
+	found := false
+	for _, live := range objects.LiveObjects() {
+		if live.TypeName == "Object1" && strings.Contains(live.Stack, "TestObjectTracking") {
+			found = true
+		}
+	}
+	assert.True(t, found)
+
+	object.Destroy()
+	assert.Equal(t, before, objects.LiveObjectCount("Object1"))
+
+	// Objects left to the garbage collector are reported as leaks
+	func() {
+		objects.NewObject0()
+	}()
+	assert.Eventually(t, func() bool {
+		runtime.GC()
+		var report strings.Builder
+		objects.ReportLeaks(&report)
+		return strings.Contains(report.String(), "Object0 was released by the garbage collector")
+	}, time.Second, 10*time.Millisecond)
+
+	// and only once, so that they don't pile up over repeated reports
+	var report strings.Builder
+	objects.ReportLeaks(&report)
+	assert.NotContains(t, report.String(), "Object0 was released by the garbage collector")
+}
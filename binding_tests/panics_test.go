@@ -0,0 +1,127 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package binding_tests
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/NordSecurity/uniffi-bindgen-go/binding_tests/generated/panics"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPanicReturnedAsError(t *testing.T) {
+	res, err := panics.PanicWith("oops")
+	assert.Equal(t, uint32(0), res)
+
+	var panicErr *panics.RustPanicError
+	if assert.True(t, errors.As(err, &panicErr)) {
+		assert.Equal(t, "oops", panicErr.Message)
+		assert.Contains(t, panicErr.Function, "panic_with")
+	}
+
+	err = panics.PanicWithoutReturn("no return")
+	assert.ErrorAs(t, err, &panicErr)
+	assert.Equal(t, "no return", panicErr.Message)
+}
+
+func TestInvalidUTF8IsReplaced(t *testing.T) {
+	// This fixture is generated with `string_utf8 = "replace"`
+	_, err := panics.PanicWith("bad \xff byte")
+
+	var panicErr *panics.RustPanicError
+	if assert.True(t, errors.As(err, &panicErr)) {
+		assert.Equal(t, "bad \uFFFD byte", panicErr.Message)
+	}
+}
+
+func TestReplacedStringAllocationSize(t *testing.T) {
+	converter := panics.FfiConverterStringINSTANCE
+	for _, value := range []string{"valid ✓", "bad \xff byte", "run \xff\xfe\xfd", "cut \xe6\x97"} {
+		var buffer bytes.Buffer
+		converter.Write(&buffer, value)
+		assert.Equal(t, uint64(buffer.Len()), converter.AllocationSize(value), "%q", value)
+	}
+
+	// Sizing does not build the replaced copy, only Write does
+	allocs := testing.AllocsPerRun(100, func() {
+		converter.AllocationSize("bad \xff byte")
+	})
+	assert.Equal(t, float64(0), allocs)
+}
+
+func TestPanicInFallibleFunction(t *testing.T) {
+	res, err := panics.CheckedDivide(10, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(5), res)
+
+	_, err = panics.CheckedDivide(10, 0)
+	assert.ErrorIs(t, err, panics.ErrDivisionErrorDivisionByZero)
+
+	var panicErr *panics.RustPanicError
+	_, err = panics.CheckedDivide(^uint32(0), 2)
+	assert.ErrorAs(t, err, &panicErr)
+	assert.Equal(t, "dividend is too large", panicErr.Message)
+}
+
+func TestPanicInObject(t *testing.T) {
+	var panicErr *panics.RustPanicError
+	_, err := panics.NewPanicker(true)
+	assert.ErrorAs(t, err, &panicErr)
+	assert.Equal(t, "constructor panicked", panicErr.Message)
+
+	panicker, err := panics.NewPanicker(false)
+	assert.NoError(t, err)
+	defer panicker.Destroy()
+
+	answer, err := panicker.Answer()
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(42), answer)
+
+	err = panicker.PanicWith("method panicked")
+	assert.ErrorAs(t, err, &panicErr)
+	assert.Equal(t, "method panicked", panicErr.Message)
+}
+
+func TestPanicInAsyncFunction(t *testing.T) {
+	var panicErr *panics.RustPanicError
+	_, err := panics.PanicAsync("async panicked")
+	assert.ErrorAs(t, err, &panicErr)
+	assert.Equal(t, "async panicked", panicErr.Message)
+
+	_, err = panics.PanicAsyncCtx(context.Background(), "ctx panicked")
+	assert.ErrorAs(t, err, &panicErr)
+	assert.Equal(t, "ctx panicked", panicErr.Message)
+
+	_, err = panics.PanicAsyncFuture("future panicked").Result()
+	assert.ErrorAs(t, err, &panicErr)
+	assert.Equal(t, "future panicked", panicErr.Message)
+}
+
+func TestLiftFailureReturnedAsError(t *testing.T) {
+	// This fixture is generated with `lift_mode = "error"`
+	_, err := panics.LongestDuration()
+
+	var liftErr *panics.LiftError
+	if assert.ErrorAs(t, err, &liftErr) {
+		assert.Equal(t, "time.Duration", liftErr.TypeName)
+		assert.Contains(t, liftErr.Reason, "does not fit into time.Duration")
+	}
+}
+
+func TestLiftFailureInAsyncFunction(t *testing.T) {
+	var liftErr *panics.LiftError
+	_, err := panics.LongestDurationAsync()
+	assert.ErrorAs(t, err, &liftErr)
+
+	_, err = panics.LongestDurationAsyncCtx(context.Background())
+	assert.ErrorAs(t, err, &liftErr)
+
+	// Futures report the *LiftError itself, not as a panic of the Rust future
+	_, err = panics.LongestDurationAsyncFuture().Result()
+	assert.IsType(t, &panics.LiftError{}, err)
+}
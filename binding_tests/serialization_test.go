@@ -0,0 +1,113 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package binding_tests
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/NordSecurity/uniffi-bindgen-go/binding_tests/generated/serialization"
+	"github.com/stretchr/testify/assert"
+)
+
+func makePoints(count int) []serialization.Point {
+	points := make([]serialization.Point, count)
+	for i := range points {
+		points[i] = serialization.Point{X: float64(i), Y: -float64(i)}
+	}
+	return points
+}
+
+func makeDrawing(layers, lines, points int) serialization.Drawing {
+	drawing := serialization.Drawing{
+		Layers:  make([][]serialization.Polyline, layers),
+		Anchors: make(map[string]serialization.Point, layers),
+	}
+	for i := range drawing.Layers {
+		drawing.Layers[i] = make([]serialization.Polyline, lines)
+		for j := range drawing.Layers[i] {
+			drawing.Layers[i][j] = serialization.Polyline{Name: fmt.Sprintf("line %d.%d", i, j), Points: makePoints(points)}
+		}
+		drawing.Anchors[fmt.Sprintf("layer %d", i)] = serialization.Point{X: float64(i)}
+	}
+	return drawing
+}
+
+func TestDrawingRoundTrip(t *testing.T) {
+	drawing := makeDrawing(3, 4, 5)
+	assert.Equal(t, drawing, serialization.EchoDrawing(drawing))
+}
+
+func TestAllocationSizeMatchesWrite(t *testing.T) {
+	drawing := makeDrawing(3, 4, 5)
+	drawing.Layers[0][0].Name = "non-ASCII ✓"
+	var buffer bytes.Buffer
+	serialization.FfiConverterDrawingINSTANCE.Write(&buffer, drawing)
+	assert.Equal(t, uint64(buffer.Len()), serialization.FfiConverterDrawingINSTANCE.AllocationSize(drawing))
+}
+
+func TestReadWriteAllocations(t *testing.T) {
+	point := serialization.Point{X: 1, Y: 2}
+	var buffer bytes.Buffer
+	buffer.Grow(64)
+	reader := bytes.NewReader(nil)
+	allocs := testing.AllocsPerRun(100, func() {
+		buffer.Reset()
+		serialization.FfiConverterPointINSTANCE.Write(&buffer, point)
+		reader.Reset(buffer.Bytes())
+		serialization.FfiConverterPointINSTANCE.Read(reader)
+	})
+	assert.Zero(t, allocs)
+}
+
+// Writes value and reads it back without calling into Rust
+func benchmarkReadWrite[T any](b *testing.B, writer serialization.BufWriter[T], reader serialization.BufReader[T], value T) {
+	var buffer bytes.Buffer
+	writer.Write(&buffer, value)
+	data := buffer.Bytes()
+
+	b.Run("Write", func(b *testing.B) {
+		b.ReportAllocs()
+		b.SetBytes(int64(len(data)))
+		var buffer bytes.Buffer
+		for i := 0; i < b.N; i++ {
+			buffer.Reset()
+			writer.Write(&buffer, value)
+		}
+	})
+	b.Run("Read", func(b *testing.B) {
+		b.ReportAllocs()
+		b.SetBytes(int64(len(data)))
+		for i := 0; i < b.N; i++ {
+			reader.Read(bytes.NewReader(data))
+		}
+	})
+}
+
+func BenchmarkRecords(b *testing.B) {
+	benchmarkReadWrite[[]serialization.Point](b, serialization.FfiConverterSequencePointINSTANCE,
+		serialization.FfiConverterSequencePointINSTANCE, makePoints(10_000))
+}
+
+func BenchmarkNestedSequences(b *testing.B) {
+	layers := makeDrawing(10, 100, 10).Layers
+	benchmarkReadWrite[[][]serialization.Polyline](b, serialization.FfiConverterSequenceSequencePolylineINSTANCE,
+		serialization.FfiConverterSequenceSequencePolylineINSTANCE, layers)
+}
+
+func BenchmarkMaps(b *testing.B) {
+	anchors := makeDrawing(10_000, 0, 0).Anchors
+	benchmarkReadWrite[map[string]serialization.Point](b, serialization.FfiConverterMapStringPointINSTANCE,
+		serialization.FfiConverterMapStringPointINSTANCE, anchors)
+}
+
+func BenchmarkEchoDrawing(b *testing.B) {
+	drawing := makeDrawing(10, 100, 10)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		serialization.EchoDrawing(drawing)
+	}
+}
@@ -0,0 +1,31 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+// Copied into the generated custom_types package by build_bindings.sh, to test its unexported helpers
+
+package custom_types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Fails the lowering if its size is computed, which would run the custom conversions twice
+type unsizedDemoWriter struct {
+	FfiConverterCustomTypesDemo
+}
+
+func (unsizedDemoWriter) AllocationSize(CustomTypesDemo) uint64 {
+	panic("AllocationSize of a value holding custom types")
+}
+
+func TestCustomTypesAreConvertedOnce(t *testing.T) {
+	_, ok := any(FfiConverterCustomTypesDemoINSTANCE).(uniffiCustomTypeWriter)
+	assert.True(t, ok)
+
+	demo := GetCustomTypesDemo(nil)
+	rbuf := LowerIntoRustBuffer[CustomTypesDemo](unsizedDemoWriter{}, demo)
+	assert.Equal(t, demo, LiftFromRustBuffer[CustomTypesDemo](FfiConverterCustomTypesDemoINSTANCE, GoRustBuffer{inner: rbuf}))
+}
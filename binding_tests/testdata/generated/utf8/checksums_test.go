@@ -0,0 +1,43 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+// Copied into the generated utf8 package by build_bindings.sh, to test its unexported helpers
+
+package utf8
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChecksumMismatchesAreAllCollected(t *testing.T) {
+	checksum := func(symbol string, expected, actual uint16) uniffiChecksum {
+		return uniffiChecksum{
+			item:     ChecksumMismatch{Symbol: symbol, Kind: "function", Name: symbol},
+			expected: expected,
+			read:     func() uint16 { return actual },
+		}
+	}
+	mismatch := &ContractMismatchError{Namespace: "utf8"}
+
+	err := uniffiCollectChecksumMismatches(mismatch, []uniffiChecksum{
+		checksum("first", 1, 2),
+		checksum("matching", 3, 3),
+		checksum("second", 4, 5),
+	})
+
+	assert.Same(t, mismatch, err)
+	assert.Equal(t, []ChecksumMismatch{
+		{Symbol: "first", Kind: "function", Name: "first"},
+		{Symbol: "second", Kind: "function", Name: "second"},
+	}, mismatch.MismatchedChecksums)
+}
+
+func TestMatchingChecksumsAreNoError(t *testing.T) {
+	err := uniffiCollectChecksumMismatches(&ContractMismatchError{}, []uniffiChecksum{
+		{expected: 1, read: func() uint16 { return 1 }},
+	})
+	assert.NoError(t, err)
+}
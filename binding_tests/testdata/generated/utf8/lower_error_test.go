@@ -0,0 +1,53 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+// Copied into the generated utf8 package by build_bindings.sh, to test its unexported helpers
+
+package utf8
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Same shape as a generated *LowerError, but without its method
+type LowerErrorLookalike struct {
+	Reason string
+}
+
+// Stands in for the *LowerError of another generated package
+type externalLowerError struct {
+	reason string
+}
+
+func (e *externalLowerError) UniffiLowerErrorReason() string {
+	return e.reason
+}
+
+func TestLowerErrorsAreRecognizedByMethod(t *testing.T) {
+	reason, ok := uniffiLowerErrorReason(&LowerError{Reason: "own"})
+	assert.True(t, ok)
+	assert.Equal(t, "own", reason)
+
+	reason, ok = uniffiLowerErrorReason(&externalLowerError{reason: "external"})
+	assert.True(t, ok)
+	assert.Equal(t, "external", reason)
+
+	_, ok = uniffiLowerErrorReason(&LowerErrorLookalike{Reason: "user"})
+	assert.False(t, ok)
+}
+
+func TestLowerArgReraisesOtherPanics(t *testing.T) {
+	assert.PanicsWithValue(t, &LowerErrorLookalike{Reason: "user"}, func() {
+		uniffiLowerArg("value", 0, func(int) int {
+			panic(&LowerErrorLookalike{Reason: "user"})
+		})
+	})
+
+	_, err := uniffiLowerArg("value", 0, func(int) int {
+		panic(&externalLowerError{reason: "external"})
+	})
+	assert.EqualError(t, err, "cannot lower argument value: external")
+}
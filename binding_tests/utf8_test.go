@@ -0,0 +1,66 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package binding_tests
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/NordSecurity/uniffi-bindgen-go/binding_tests/generated/utf8"
+	"github.com/stretchr/testify/assert"
+)
+
+const invalidUTF8 = "caf\xc3"
+
+func TestValidStringsPass(t *testing.T) {
+	assert.Equal(t, "café with 1 tags",
+		utf8.DescribeProfile(utf8.Profile{Name: "café", Tags: map[string]string{"ü": "ß"}}))
+
+	count, err := utf8.CountTags(map[string]string{"a": "1", "b": "2"})
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(2), count)
+}
+
+func TestInvalidUTF8InRecordField(t *testing.T) {
+	defer func() {
+		lowerErr, ok := recover().(*utf8.LowerError)
+		if assert.True(t, ok) {
+			assert.Equal(t, "profile", lowerErr.Argument)
+			assert.Equal(t, "string is not valid UTF-8", lowerErr.Reason)
+		}
+	}()
+	utf8.DescribeProfile(utf8.Profile{Name: invalidUTF8})
+}
+
+func TestInvalidUTF8InMap(t *testing.T) {
+	_, err := utf8.CountTags(map[string]string{"key": invalidUTF8})
+	var lowerErr *utf8.LowerError
+	if assert.True(t, errors.As(err, &lowerErr)) {
+		assert.Equal(t, "tags", lowerErr.Argument)
+	}
+
+	_, err = utf8.CountTags(map[string]string{invalidUTF8: "value"})
+	assert.ErrorAs(t, err, &lowerErr)
+}
+
+type nameSource struct {
+	name string
+}
+
+func (s nameSource) Name() string {
+	return s.name
+}
+
+func TestInvalidUTF8InCallbackReturn(t *testing.T) {
+	assert.Equal(t, "Hello, Go", utf8.Greet(nameSource{"Go"}))
+
+	defer func() {
+		panicErr, ok := recover().(*utf8.RustPanicError)
+		if assert.True(t, ok) {
+			assert.Contains(t, panicErr.Message, "string is not valid UTF-8")
+		}
+	}()
+	utf8.Greet(nameSource{invalidUTF8})
+}
@@ -19,3 +19,8 @@ else
 LIB_FILE="$BINARIES_DIR/libuniffi_fixtures.so"
 fi
 target/debug/uniffi-bindgen-go "$LIB_FILE" --out-dir "$BINDINGS_DIR" --config "$ROOT_DIR/fixtures/uniffi.toml"
+
+# Tests of unexported helpers and fuzz seed corpora have to live in the generated packages
+for package in "$ROOT_DIR"/binding_tests/testdata/generated/*/; do
+	cp -R "$package". "$BINDINGS_DIR/$(basename "$package")/"
+done
@@ -0,0 +1,75 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+use std::sync::Mutex;
+
+pub enum Priority {
+    Low,
+    Normal,
+    High,
+}
+
+pub struct Order {
+    pub item: String,
+    pub quantity: u32,
+    pub offset: i64,
+    pub discount: f64,
+    pub gift: bool,
+    pub note: String,
+    pub coupon: Option<String>,
+    pub tags: Vec<String>,
+    pub priority: Priority,
+}
+
+pub struct Settings {
+    pub retries: u16,
+    pub verbose: bool,
+}
+
+pub struct Shop {
+    name: String,
+    stock: Mutex<u32>,
+}
+
+impl Shop {
+    fn new(name: String, stock: u32) -> Self {
+        Shop {
+            name,
+            stock: Mutex::new(stock),
+        }
+    }
+
+    fn with_stock(stock: u32, name: String) -> Self {
+        Self::new(name, stock)
+    }
+
+    fn name(&self) -> String {
+        self.name.clone()
+    }
+
+    fn stock(&self) -> u32 {
+        *self.stock.lock().unwrap()
+    }
+
+    fn checkout(&self, item: String, quantity: u32, gift: bool) -> String {
+        let mut stock = self.stock.lock().unwrap();
+        *stock = stock.saturating_sub(quantity);
+        let wrapping = if gift { ", gift wrapped" } else { "" };
+        format!("{quantity} x {item}{wrapping}")
+    }
+}
+
+fn greet(name: String, greeting: String, times: u32) -> String {
+    vec![format!("{greeting}, {name}!"); times as usize].join(" ")
+}
+
+fn echo_order(order: Order) -> Order {
+    order
+}
+
+fn echo_settings(settings: Settings) -> Settings {
+    settings
+}
+
+include!(concat!(env!("OUT_DIR"), "/defaults.uniffi.rs"));
@@ -8,6 +8,12 @@ use std::sync::{Arc, RwLock};
 
 static LIVE_COUNT: Lazy<RwLock<i32>> = Lazy::new(|| RwLock::new(0));
 
+#[derive(Debug, thiserror::Error)]
+pub enum ResourceError {
+    #[error("resource is busy")]
+    Busy,
+}
+
 #[derive(Debug, Clone)]
 pub struct Resource {}
 
@@ -16,6 +22,21 @@ impl Resource {
         *LIVE_COUNT.write().unwrap() += 1;
         Resource {}
     }
+
+    pub fn is_alive(&self) -> bool {
+        true
+    }
+
+    pub fn check_out(&self, busy: bool) -> Result<(), ResourceError> {
+        if busy {
+            return Err(ResourceError::Busy);
+        }
+        Ok(())
+    }
+
+    pub fn lend(&self, _delay: std::time::Duration) {}
+
+    pub fn hand_over(&self, _journal: ResourceJournal, _successor: Arc<Resource>) {}
 }
 
 impl Drop for Resource {
@@ -85,4 +106,8 @@ fn get_live_count() -> i32 {
     *LIVE_COUNT.read().unwrap()
 }
 
+fn keep_journal(_journal: ResourceJournal, _delay: std::time::Duration) {}
+
+fn keep_resource(_journal: ResourceJournal, _resource: Arc<Resource>) {}
+
 include!(concat!(env!("OUT_DIR"), "/destroy.uniffi.rs"));
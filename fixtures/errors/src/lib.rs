@@ -63,6 +63,29 @@ impl Vec2 {
     }
 }
 
+pub enum Severity {
+    Low,
+    High,
+}
+
+pub enum Hazard {
+    Ice {
+        severity: Severity,
+        location: String,
+    },
+    Clear,
+}
+
+fn hazard_at(location: String) -> Hazard {
+    if location.is_empty() {
+        return Hazard::Clear;
+    }
+    Hazard::Ice {
+        severity: Severity::High,
+        location,
+    }
+}
+
 #[uniffi::export]
 fn try_nested(trip: bool) -> Result<(), NestedError> {
     if trip {
@@ -584,4 +584,82 @@ async fn cancel_delay_using_trait(obj: Arc<dyn AsyncParser>, delay_ms: i32) {
     assert_eq!(future.await, Err(Aborted));
 }
 
+// Object streaming its items through an async method, see `uniffi.toml`
+#[derive(uniffi::Object)]
+pub struct Countdown {
+    remaining: Mutex<u32>,
+    fail_at: Option<u32>,
+}
+
+#[uniffi::export]
+impl Countdown {
+    #[uniffi::constructor]
+    pub fn new(from: u32, fail_at: Option<u32>) -> Arc<Self> {
+        Arc::new(Self {
+            remaining: Mutex::new(from),
+            fail_at,
+        })
+    }
+
+    pub async fn tick(&self) -> Result<Option<u32>, MyError> {
+        TimerFuture::new(Duration::from_millis(1)).await;
+
+        let mut remaining = self.remaining.lock().unwrap();
+        if *remaining == 0 {
+            return Ok(None);
+        }
+        *remaining -= 1;
+        if Some(*remaining) == self.fail_at {
+            return Err(MyError::Foo);
+        }
+        Ok(Some(*remaining))
+    }
+}
+
+// Async trait whose Go implementations receive a `context.Context`, see `uniffi.toml`
+#[uniffi::export(with_foreign)]
+#[async_trait::async_trait]
+pub trait CancellableWorker: Send + Sync {
+    // Returns true when the work was done and false when it was interrupted
+    async fn work(&self, delay_ms: i32) -> bool;
+
+    // Negative delays fail to lower in Go, without a ctx error
+    async fn rest(&self, delay: Duration);
+}
+
+struct RustWorker;
+
+#[async_trait::async_trait]
+impl CancellableWorker for RustWorker {
+    async fn work(&self, delay_ms: i32) -> bool {
+        sleep(delay_ms as u16).await
+    }
+
+    async fn rest(&self, delay: Duration) {
+        TimerFuture::new(delay).await;
+    }
+}
+
+#[uniffi::export]
+fn new_rust_worker() -> Arc<dyn CancellableWorker> {
+    Arc::new(RustWorker)
+}
+
+#[uniffi::export]
+async fn work_using_trait(obj: Arc<dyn CancellableWorker>, delay_ms: i32) -> bool {
+    obj.work(delay_ms).await
+}
+
+#[uniffi::export]
+async fn cancel_work_using_trait(obj: Arc<dyn CancellableWorker>, delay_ms: i32) {
+    let (abort_handle, abort_registration) = AbortHandle::new_pair();
+    thread::spawn(move || {
+        // Simulate a different thread aborting the process
+        thread::sleep(Duration::from_millis(1));
+        abort_handle.abort();
+    });
+    let future = Abortable::new(obj.work(delay_ms), abort_registration);
+    assert_eq!(future.await, Err(Aborted));
+}
+
 uniffi::include_scaffolding!("futures");
@@ -0,0 +1,74 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+pub enum Direction {
+    North,
+    South,
+}
+
+pub enum Shape {
+    Circle { radius: f64 },
+    Square { side: f64 },
+}
+
+pub struct Drawing {
+    shape: Shape,
+    title: String,
+}
+
+#[derive(Debug, thiserror::Error)]
+pub enum FetchError {
+    #[error("Timed out")]
+    Timeout,
+}
+
+#[derive(Debug, thiserror::Error)]
+pub enum ParseError {
+    #[error("Syntax error on line {line}")]
+    Syntax { line: u32 },
+}
+
+fn opposite(direction: Direction) -> Direction {
+    match direction {
+        Direction::North => Direction::South,
+        Direction::South => Direction::North,
+    }
+}
+
+fn describe(shape: Shape) -> String {
+    match shape {
+        Shape::Circle { radius } => format!("circle of radius {radius}"),
+        Shape::Square { side } => format!("square of side {side}"),
+    }
+}
+
+fn describe_all(shapes: Vec<Shape>) -> String {
+    shapes
+        .into_iter()
+        .map(describe)
+        .collect::<Vec<_>>()
+        .join(", ")
+}
+
+fn describe_drawing(drawing: Drawing) -> String {
+    format!("{}: {}", drawing.title, describe(drawing.shape))
+}
+
+fn fetch(fail: bool) -> Result<(), FetchError> {
+    if fail {
+        return Err(FetchError::Timeout);
+    }
+    Ok(())
+}
+
+fn parse(input: String) -> Result<(), ParseError> {
+    match input.lines().position(|line| line.trim().is_empty()) {
+        Some(line) => Err(ParseError::Syntax {
+            line: line as u32 + 1,
+        }),
+        None => Ok(()),
+    }
+}
+
+include!(concat!(env!("OUT_DIR"), "/non_exhaustive.uniffi.rs"));
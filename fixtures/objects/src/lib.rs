@@ -4,6 +4,7 @@
 
 use crossbeam::channel::{Receiver, Sender};
 use once_cell::sync::Lazy;
+use std::collections::HashMap;
 use std::sync::{Arc, Mutex};
 
 static RECEIVER_COUNT: Lazy<Mutex<i32>> = Lazy::new(|| Mutex::new(0));
@@ -179,6 +180,25 @@ pub fn return_object1(object: Arc<Object1>) -> Arc<Object1> {
     object
 }
 
+pub struct Object1Holder {
+    pub object: Arc<Object1>,
+}
+
+pub fn get_holder_message(holder: Object1Holder) -> String {
+    holder.object.get_message()
+}
+
+pub fn get_messages(objects: Vec<Arc<Object1>>) -> Vec<String> {
+    objects.iter().map(|o| o.get_message()).collect()
+}
+
+pub fn get_message_map(objects: HashMap<String, Arc<Object1>>) -> HashMap<String, String> {
+    objects
+        .into_iter()
+        .map(|(k, o)| (k, o.get_message()))
+        .collect()
+}
+
 fn get_live_receiver_count() -> i32 {
     *RECEIVER_COUNT.lock().unwrap()
 }
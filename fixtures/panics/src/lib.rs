@@ -0,0 +1,61 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+#[derive(Debug, thiserror::Error)]
+pub enum DivisionError {
+    #[error("Division by zero")]
+    DivisionByZero,
+}
+
+fn panic_with(message: String) -> u32 {
+    panic!("{message}");
+}
+
+fn panic_without_return(message: String) {
+    panic!("{message}");
+}
+
+fn checked_divide(dividend: u32, divisor: u32) -> Result<u32, DivisionError> {
+    if divisor == 0 {
+        return Err(DivisionError::DivisionByZero);
+    }
+    if dividend == u32::MAX {
+        panic!("dividend is too large");
+    }
+    Ok(dividend / divisor)
+}
+
+async fn panic_async(message: String) -> u32 {
+    panic!("{message}");
+}
+
+// Longer than time.Duration can hold, so lifting it fails in Go
+fn longest_duration() -> std::time::Duration {
+    std::time::Duration::MAX
+}
+
+async fn longest_duration_async() -> std::time::Duration {
+    std::time::Duration::MAX
+}
+
+pub struct Panicker {}
+
+impl Panicker {
+    pub fn new(panic: bool) -> Self {
+        if panic {
+            panic!("constructor panicked");
+        }
+        Panicker {}
+    }
+
+    pub fn answer(&self) -> u32 {
+        42
+    }
+
+    pub fn panic_with(&self, message: String) {
+        panic!("{message}");
+    }
+}
+
+include!(concat!(env!("OUT_DIR"), "/panics.uniffi.rs"));
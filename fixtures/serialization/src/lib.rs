@@ -0,0 +1,48 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+use std::collections::HashMap;
+
+pub struct Point {
+    pub x: f64,
+    pub y: f64,
+}
+
+pub struct Polyline {
+    pub name: String,
+    pub points: Vec<Point>,
+}
+
+pub struct Drawing {
+    pub layers: Vec<Vec<Polyline>>,
+    pub anchors: HashMap<String, Point>,
+}
+
+fn echo_drawing(drawing: Drawing) -> Drawing {
+    drawing
+}
+
+fn repeat_string(value: String, count: u32) -> String {
+    value.repeat(count as usize)
+}
+
+fn repeat_bytes(value: Vec<u8>, count: u32) -> Vec<u8> {
+    value.repeat(count as usize)
+}
+
+pub struct Payload {
+    text: String,
+}
+
+impl Payload {
+    fn new(text: String) -> Self {
+        Self { text }
+    }
+
+    fn text(&self) -> String {
+        self.text.clone()
+    }
+}
+
+include!(concat!(env!("OUT_DIR"), "/serialization.uniffi.rs"));
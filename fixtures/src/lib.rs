@@ -40,5 +40,10 @@ mod uniffi_fixtures {
     uniffi_go_issue45::uniffi_reexport_scaffolding!();
     uniffi_go_name_case::uniffi_reexport_scaffolding!();
     uniffi_go_objects::uniffi_reexport_scaffolding!();
+    uniffi_go_panics::uniffi_reexport_scaffolding!();
+    uniffi_go_non_exhaustive::uniffi_reexport_scaffolding!();
+    uniffi_go_utf8::uniffi_reexport_scaffolding!();
+    uniffi_go_serialization::uniffi_reexport_scaffolding!();
+    uniffi_go_defaults::uniffi_reexport_scaffolding!();
     uniffi_go_empty_string_and_bytes::uniffi_reexport_scaffolding!();
 }
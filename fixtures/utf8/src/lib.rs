@@ -0,0 +1,37 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+use std::collections::HashMap;
+
+pub struct Profile {
+    pub name: String,
+    pub tags: HashMap<String, String>,
+}
+
+#[derive(Debug, thiserror::Error)]
+pub enum TagError {
+    #[error("No tags given")]
+    NoTags,
+}
+
+pub trait NameSource: Send + Sync {
+    fn name(&self) -> String;
+}
+
+fn describe_profile(profile: Profile) -> String {
+    format!("{} with {} tags", profile.name, profile.tags.len())
+}
+
+fn count_tags(tags: HashMap<String, String>) -> Result<u32, TagError> {
+    if tags.is_empty() {
+        return Err(TagError::NoTags);
+    }
+    Ok(tags.len() as u32)
+}
+
+fn greet(source: Box<dyn NameSource>) -> String {
+    format!("Hello, {}", source.name())
+}
+
+include!(concat!(env!("OUT_DIR"), "/utf8.uniffi.rs"));
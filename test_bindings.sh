@@ -7,18 +7,21 @@ ROOT_DIR="$SCRIPT_DIR"
 BINDINGS_DIR="$ROOT_DIR/binding_tests"
 BINARIES_DIR="$ROOT_DIR/target/debug"
 
+# Generated packages with tests of their own, such as the targets of `fuzz_tests` run on their seeds
+PACKAGES=". ./generated/utf8 ./generated/custom_types ./generated/errors ./generated/serialization"
+
 if [ -n "${1:-}" ]; then
 	if [ -f "$BINDINGS_DIR/${1}" ]; then
 		SELECT="$BINDINGS_DIR/${1}"
 	else
-		SELECT="-run ${1}"
+		SELECT="-run ${1} $PACKAGES"
 	fi
 else
-	SELECT=""
+	SELECT="$PACKAGES"
 fi
 
 pushd $BINDINGS_DIR
 LD_LIBRARY_PATH="${LD_LIBRARY_PATH:-}:$BINARIES_DIR" \
 	CGO_LDFLAGS="-luniffi_fixtures -L$BINARIES_DIR -lm -ldl" \
 	CGO_ENABLED=1 \
-	go test -v $SELECT
+	go test -v ${GO_TEST_FLAGS:-} $SELECT